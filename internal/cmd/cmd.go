@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -12,7 +13,10 @@ import (
 
 	"github.com/rafaelespinoza/alf"
 
+	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/entity/date"
 	"github.com/rafaelespinoza/ged/internal/log"
+	"github.com/rafaelespinoza/ged/internal/srv"
 )
 
 var (
@@ -20,6 +24,10 @@ var (
 		loggingOff bool
 		logLevel   string
 		logFormat  string
+		dateOrder  string
+		// dateParseOptions is derived from dateOrder, and is passed along to
+		// whatever interprets dates in the input.
+		dateParseOptions date.ParseOptions
 	}
 )
 
@@ -46,6 +54,7 @@ func New() Root {
 	rootFlags.BoolVar(&args.loggingOff, "q", false, "if true, then all logging is effectively off")
 	rootFlags.StringVar(&args.logLevel, "loglevel", validLoggingLevels[len(validLoggingLevels)-1].String(), fmt.Sprintf("minimum severity for which to log events, should be one of %q", validLoggingLevels))
 	rootFlags.StringVar(&args.logFormat, "logformat", validLoggingFormats[len(validLoggingFormats)-1], fmt.Sprintf("output format for logs, should be one of %q", validLoggingFormats))
+	rootFlags.StringVar(&args.dateOrder, "date-order", date.DayFirst.String(), fmt.Sprintf("order of the day and month in ambiguous numeric dates, such as 12/03/1890, should be one of %q", []string{date.DayFirst.String(), date.MonthFirst.String()}))
 
 	rootFlags.Usage = func() {
 		fmt.Fprintf(rootFlags.Output(), `%s
@@ -61,6 +70,11 @@ Description:
 	structured data, written to STDERR. Messages have an associated "Level", to
 	describe the severity of an event.
 
+	Dates are read leniently. Besides the GEDCOM format, dates may be written
+	with Spanish, German, Dutch or French words, or numerically, such as
+	1890-03-12 or 12/03/1890. The order of the day and month in the latter is
+	ambiguous, so it's a flag.
+
 	Each subcommand may have its own flags, which would be defined there.
 
 	The following flags are top-level and should go before the subcommand.
@@ -96,6 +110,12 @@ Examples:
 				return err
 			}
 			log.Init(handler)
+
+			order, err := date.ParseNumericOrder(args.dateOrder)
+			if err != nil {
+				return err
+			}
+			args.dateParseOptions = date.ParseOptions{NumericOrder: order}
 			return nil
 		},
 	}
@@ -155,9 +175,79 @@ func printFlagDefaults(f *flag.FlagSet) {
 	f.PrintDefaults()
 }
 
+// readEntities reads the people and unions in the input format, either
+// "gedcom" or "json". The JSON should have the fields "people" and "unions".
+func readEntities(ctx context.Context, in io.Reader, inputFormat string) (people []*entity.Person, unions []*entity.Union, err error) {
+	switch inputFormat {
+	case "json":
+		var data struct {
+			People []*entity.Person
+			Unions []*entity.Union
+		}
+		if err = readJSON(in, &data); err != nil {
+			return
+		}
+		people, unions = data.People, data.Unions
+	default:
+		people, unions, err = srv.ParseGedcom(ctx, in, args.dateParseOptions)
+	}
+	return
+}
+
 func readJSON(in io.Reader, out any) error    { return json.NewDecoder(in).Decode(out) }
 func writeJSON(out io.Writer, data any) error { return json.NewEncoder(out).Encode(data) }
 
 func initUsageLine(subcmd string) string {
 	return fmt.Sprintf("Usage: %s [%s-flags] %s [%s-flags]", mainName, mainName, subcmd, subcmd)
 }
+
+// estimateDatesFlags are the options for estimating unknown birth and death
+// dates. Subcommands that can estimate dates should share these so that the
+// flags are named and described the same way.
+type estimateDatesFlags struct {
+	enabled bool
+	params  srv.EstimateParams
+}
+
+func (f *estimateDatesFlags) register(flags *flag.FlagSet) {
+	defaults := srv.DefaultEstimateParams
+	flags.BoolVar(&f.enabled, "estimate-dates", false, "estimate unknown birth and death dates from relatives, estimates are marked as such")
+	flags.IntVar(&f.params.MinGeneration, "estimate-min-generation", defaults.MinGeneration, "youngest age of a parent, in years, when estimating dates")
+	flags.IntVar(&f.params.MaxGeneration, "estimate-max-generation", defaults.MaxGeneration, "oldest age of a parent, in years, when estimating dates")
+	flags.IntVar(&f.params.MaxSpouseAgeGap, "estimate-max-spouse-age-gap", defaults.MaxSpouseAgeGap, "most years between the births of partners when estimating dates")
+	flags.IntVar(&f.params.MaxLifespan, "estimate-max-lifespan", defaults.MaxLifespan, "oldest age at death, in years, when estimating dates")
+}
+
+// apply estimates dates for the people, but only if it's enabled.
+func (f *estimateDatesFlags) apply(ctx context.Context, people []*entity.Person, unions []*entity.Union) error {
+	if !f.enabled {
+		return nil
+	}
+	if f.params.MinGeneration < 1 || f.params.MinGeneration > f.params.MaxGeneration {
+		return fmt.Errorf("invalid estimate-min-generation %d, should be positive and at most estimate-max-generation %d", f.params.MinGeneration, f.params.MaxGeneration)
+	}
+	if f.params.MaxSpouseAgeGap < 1 || f.params.MaxLifespan < 1 {
+		return errors.New("estimate-max-spouse-age-gap and estimate-max-lifespan should be positive")
+	}
+	srv.EstimateDates(ctx, people, unions, f.params)
+	return nil
+}
+
+// livingFlags are the options for inferring if people are living.
+type livingFlags struct {
+	params srv.LivingParams
+}
+
+func (f *livingFlags) register(flags *flag.FlagSet) {
+	flags.IntVar(&f.params.MaxAge, "living-max-age", srv.DefaultLivingParams.MaxAge, "oldest age of a living person, in years, anyone older is presumed deceased")
+}
+
+// apply infers if each person is living. It should happen after estimating
+// dates, because estimates help with the inference.
+func (f *livingFlags) apply(ctx context.Context, people []*entity.Person) error {
+	if f.params.MaxAge < 1 {
+		return fmt.Errorf("invalid living-max-age %d, should be positive", f.params.MaxAge)
+	}
+	srv.InferLiving(ctx, people, f.params)
+	return nil
+}
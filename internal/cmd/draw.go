@@ -19,6 +19,7 @@ func makeDraw(name string) alf.Directive {
 	var flowchartDirection, inputFormat, outputFormat string
 	var displayID bool
 	var renderPNGScale float64
+	var estimates estimateDatesFlags
 
 	const mermaid = "mermaid"
 
@@ -37,6 +38,7 @@ func makeDraw(name string) alf.Directive {
 
 			flags.StringVar(&outputFormat, "output-format", supportedOutputFormats[0], fmt.Sprintf("format of output data, one of %q", supportedOutputFormats))
 			flags.Float64Var(&renderPNGScale, "render-png-scale", 10.0, "scaling factor for rendering PNG")
+			estimates.register(flags)
 
 			flags.Usage = func() {
 				fmt.Fprintf(flags.Output(), `%s < path/to/input
@@ -55,6 +57,9 @@ Flowchart-related options:
 	If display-id is true, then each person node will have the ID of the person displayed.
 	This ID can be helpful for additional introspection.
 
+	If estimate-dates is true, then a person without a birth or death date may
+	have a range of years estimated from their relatives, like "est. 1816...1824".
+
 Input-related options:
 	If you'd prefer to make some manual edits to the Mermaid flowchart, and you
 	want to render it again, just specify -input-format=%s.
@@ -102,7 +107,7 @@ Examples:
 				return
 			} else if outputFormat == mermaid {
 				// take in GEDCOM data and generate a Mermaid flowchart
-				err = makeMermaidFlowchart(ctx, os.Stdin, os.Stdout, flowchartDirection, displayID)
+				err = makeMermaidFlowchart(ctx, os.Stdin, os.Stdout, flowchartDirection, displayID, &estimates)
 				return
 			} else if inputFormat == mermaid {
 				// take in a Mermaid flowchart, and render it (via Mermaid) as SVG or PNG
@@ -114,7 +119,7 @@ Examples:
 			// render that flowchart as SVG or PNG.
 
 			chartIO := new(bytes.Buffer)
-			if err = makeMermaidFlowchart(ctx, os.Stdin, chartIO, flowchartDirection, displayID); err != nil {
+			if err = makeMermaidFlowchart(ctx, os.Stdin, chartIO, flowchartDirection, displayID, &estimates); err != nil {
 				return
 			}
 
@@ -126,18 +131,20 @@ Examples:
 	return &out
 }
 
-func makeMermaidFlowchart(ctx context.Context, r io.Reader, w io.Writer, flowchartDirection string, displayID bool) (err error) {
-	people, unions, err := srv.ParseGedcom(ctx, r)
+func makeMermaidFlowchart(ctx context.Context, r io.Reader, w io.Writer, flowchartDirection string, displayID bool, estimates *estimateDatesFlags) (err error) {
+	people, unions, err := srv.ParseGedcom(ctx, r, args.dateParseOptions)
 	if err != nil {
 		return
 	}
+	if err = estimates.apply(ctx, people, unions); err != nil {
+		return
+	}
 
 	err = srv.MakeMermaidFlowchart(ctx, srv.MermaidFlowchartParams{
 		Direction: flowchartDirection,
 		DisplayID: displayID,
 		Out:       w,
-		People:    people,
-		Unions:    unions,
+		Graph:     srv.NewGraph(people, unions),
 	})
 
 	return
@@ -11,8 +11,13 @@ func makeExploreData(name string) alf.Directive {
 	out := alf.Delegator{
 		Description: "view GEDCOM data",
 		Subs: map[string]alf.Directive{
-			"show":   makeExploreDataShow(name, "show"),
-			"relate": makeExploreDataRelate(name, "relate"),
+			"show":       makeExploreDataShow(name, "show"),
+			"relate":     makeExploreDataRelate(name, "relate"),
+			"relate-all": makeExploreDataRelateAll(name, "relate-all"),
+			"inbreeding": makeExploreDataInbreeding(name, "inbreeding"),
+			"cm":         makeExploreDataCM(name, "cm"),
+			"dna-lines":  makeExploreDataDNALines(name, "dna-lines"),
+			"kinship":    makeExploreDataKinship(name, "kinship"),
 		},
 		Flags: newFlagSet(mainName),
 	}
@@ -41,6 +46,7 @@ type (
 		FamiliesAsChild   []groupSheetFamily
 		FamiliesAsPartner []groupSheetFamily
 		Events            []*groupSheetEvent
+		Ordinances        []*groupSheetOrdinance
 	}
 	groupSheetSimplePerson struct {
 		ID    string
@@ -48,6 +54,10 @@ type (
 		Name  string
 		Birth groupSheetDate
 		Death groupSheetDate
+		// Age is the age at death, if known.
+		Age string
+		// Living is one of "living", "deceased" or "unknown".
+		Living string
 	}
 	groupSheetFamily struct {
 		ID         string
@@ -58,10 +68,19 @@ type (
 		Children   []*groupSheetSimplePerson
 	}
 	groupSheetEvent struct {
-		Date  groupSheetDate
+		Date groupSheetDate
+		// Age is the age of the person at the time of the event, if known.
+		Age   string
 		Type  string
 		Notes []string
 	}
+	groupSheetOrdinance struct {
+		Date   groupSheetDate
+		Type   string
+		Temple string
+		Status string
+		Notes  []string
+	}
 	groupSheetDate struct {
 		Date  string
 		Place string
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -33,10 +34,10 @@ func init() {
 
 func renderGroupSheetView(w io.Writer, in *groupSheetView) error {
 	headerStyles := styleBoldUnderline.Copy().MarginBottom(1)
-	var personView, familiesAsChild, familiesAsPartner, events strings.Builder
+	var personView, familiesAsChild, familiesAsPartner, events, ordinances strings.Builder
 	{
 		personView.WriteString(headerStyles.Render("person") + "\n")
-		personView.WriteString(tableizeGroupSheetPeople([]string{"id", "name", "birth_date", "birth_place", "death_date", "death_place"}, in.Person) + "\n")
+		personView.WriteString(tableizeGroupSheetPeople([]string{"id", "name", "birth_date", "birth_place", "death_date", "death_place", "living"}, in.Person) + "\n")
 		for _, note := range in.Notes {
 			personView.WriteString(styleFaint.Copy().Width(80).Render(note) + "\n")
 		}
@@ -56,7 +57,14 @@ func renderGroupSheetView(w io.Writer, in *groupSheetView) error {
 		events.WriteString(listEvents(in.Events))
 	}
 
-	_, err := fmt.Fprintln(w, lipgloss.JoinVertical(lipgloss.Center, personView.String(), familiesAsChild.String(), familiesAsPartner.String(), events.String()))
+	sections := []string{personView.String(), familiesAsChild.String(), familiesAsPartner.String(), events.String()}
+	if len(in.Ordinances) > 0 {
+		ordinances.WriteString(headerStyles.Render("ordinances") + "\n")
+		ordinances.WriteString(listOrdinances(in.Ordinances))
+		sections = append(sections, ordinances.String())
+	}
+
+	_, err := fmt.Fprintln(w, lipgloss.JoinVertical(lipgloss.Center, sections...))
 	return err
 }
 
@@ -77,7 +85,7 @@ func buildFamilyComponent(fam groupSheetFamily) string {
 	}
 	people := append(fam.Parents, fam.Children...)
 	if len(people) > 0 {
-		columns := []string{"role", "name", "birth_date", "birth_place", "death_date", "death_place"}
+		columns := []string{"role", "name", "birth_date", "birth_place", "death_date", "death_place", "living"}
 		parts = append(parts, tableizeGroupSheetPeople(columns, people...))
 	}
 
@@ -113,7 +121,12 @@ Also show the inverse: from person 2 to person 1.`) + "\n",
 		// applicable to the relationships.
 		common := make([]string, 0)
 		columns := []string{"name", "birth_date", "birth_place", "death_date", "death_place"}
-		if in.CommonPerson != nil {
+		if len(in.CommonAncestors) > 1 {
+			var b strings.Builder
+			b.WriteString(styleBold.Render("common ancestors") + "\n")
+			b.WriteString(tableizeGroupSheetPeople(columns, in.CommonAncestors...) + "\n")
+			common = append(common, b.String())
+		} else if in.CommonPerson != nil {
 			var b strings.Builder
 			b.WriteString(styleBold.Render("common ancestor") + "\n")
 			b.WriteString(tableizeGroupSheetPeople(columns, in.CommonPerson) + "\n")
@@ -138,10 +151,48 @@ If related by law, through which union?`) + "\n",
 		)
 	}
 
-	_, err := fmt.Fprintln(w, lipgloss.JoinVertical(lipgloss.Center, relationships.String(), commonEntities.String()))
+	parts := []string{relationships.String(), commonEntities.String()}
+	if in.Coefficients != nil {
+		parts = append(parts, renderCoefficients(in))
+	}
+
+	_, err := fmt.Fprintln(w, lipgloss.JoinVertical(lipgloss.Center, parts...))
 	return err
 }
 
+func renderCoefficients(in mutualRelationship) string {
+	var b strings.Builder
+	b.WriteString(styleBold.Render("coefficients") + "\n")
+	b.WriteString(styleFaint.Render(`What fraction of DNA do the people share by descent?
+How inbred is each person? Only blood relationships through birth parents count.
+How many centimorgans of DNA are expected for the relationship?`) + "\n")
+
+	out := table.New().
+		Headers("coefficient", "value").
+		StyleFunc(getTableRowStyle).
+		BorderRow(true).
+		BorderStyle(styleFaint).
+		Row("relationship", formatCoefficient(in.Coefficients.Relationship)).
+		Row("inbreeding of "+in.Person1.Name, formatCoefficient(in.Coefficients.Inbreeding1)).
+		Row("inbreeding of "+in.Person2.Name, formatCoefficient(in.Coefficients.Inbreeding2))
+	if in.SharedCM != nil {
+		out = out.Row("expected shared cM", formatSharedCM(in.SharedCM))
+	}
+	b.WriteString(out.Render() + "\n")
+	return b.String()
+}
+
+// formatSharedCM shows the range of shared DNA, and its average.
+func formatSharedCM(in *sharedCM) string {
+	return fmt.Sprintf("%.0f - %.0f (average %.0f)", in.Low, in.High, in.Average)
+}
+
+// formatCoefficient shows a coefficient as a percentage, with enough digits
+// for distant relationships.
+func formatCoefficient(in float64) string {
+	return strconv.FormatFloat(in*100, 'g', 4, 64) + "%"
+}
+
 // buildPersonVertically formats the person fields in a vertical orientation. It
 // ensures that the field names and values are aligned in a tabular fashion.
 func buildPersonVertically(in *groupSheetSimplePerson) string {
@@ -153,6 +204,8 @@ func buildPersonVertically(in *groupSheetSimplePerson) string {
 		{"birth_place", in.Birth.Place},
 		{"death_date", in.Death.Date},
 		{"death_place", in.Death.Place},
+		{"age_at_death", in.Age},
+		{"living", in.Living},
 		{"id", in.ID},
 	}
 	tail := "\n"
@@ -207,6 +260,8 @@ func tableizeGroupSheetPeople(columnNames []string, people ...*groupSheetSimpleP
 				value = p.Death.Date
 			case "death_place":
 				value = p.Death.Place
+			case "living":
+				value = p.Living
 			default:
 				log.Warn(context.TODO(), map[string]any{"column_name": column}, "tableizeGroupSheetPeople: unmapped column")
 			}
@@ -220,7 +275,7 @@ func tableizeGroupSheetPeople(columnNames []string, people ...*groupSheetSimpleP
 
 func listEvents(in []*groupSheetEvent) string {
 	out := table.New().
-		Headers("date", "place", "type", "notes").
+		Headers("date", "age", "place", "type", "notes").
 		StyleFunc(getTableRowStyle).
 		BorderRow(true).
 		BorderStyle(styleFaint)
@@ -230,6 +285,7 @@ func listEvents(in []*groupSheetEvent) string {
 	for _, ev := range in {
 		out = out.Row(
 			ev.Date.Date,
+			ev.Age,
 			wrappingStyle.Render(ev.Date.Place),
 			ev.Type,
 			wrappingStyle.Render(strings.Join(ev.Notes, "\n")),
@@ -238,6 +294,28 @@ func listEvents(in []*groupSheetEvent) string {
 	return out.Render()
 }
 
+func listOrdinances(in []*groupSheetOrdinance) string {
+	out := table.New().
+		Headers("date", "place", "temple", "type", "status", "notes").
+		StyleFunc(getTableRowStyle).
+		BorderRow(true).
+		BorderStyle(styleFaint)
+
+	wrappingStyle := styleTableRow.Copy().Width(40)
+
+	for _, ord := range in {
+		out = out.Row(
+			ord.Date.Date,
+			wrappingStyle.Render(ord.Date.Place),
+			ord.Temple,
+			ord.Type,
+			ord.Status,
+			wrappingStyle.Render(strings.Join(ord.Notes, "\n")),
+		)
+	}
+	return out.Render()
+}
+
 func getTableRowStyle(row, col int) lipgloss.Style {
 	if row == 0 {
 		return styleTableHeader
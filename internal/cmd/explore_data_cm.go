@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/rafaelespinoza/alf"
+
+	"github.com/rafaelespinoza/ged/internal/srv"
+)
+
+func makeExploreDataCM(parentName, name string) alf.Directive {
+	var inputFormat, outputFormat, personID string
+	var cm float64
+	var living livingFlags
+	supportedInputFormats := []string{"gedcom", "json"}
+	supportedOutputFormats := []string{"", "json"}
+	out := alf.Command{
+		Description: "find relatives that fit an amount of shared DNA",
+		Setup: func(p flag.FlagSet) *flag.FlagSet {
+			fullName := mainName + " " + parentName + " " + name
+			flags := newFlagSet(fullName)
+			flags.StringVar(&inputFormat, "f", supportedInputFormats[0], fmt.Sprintf("input format, one of %q", supportedInputFormats))
+			flags.StringVar(&outputFormat, "output-format", supportedOutputFormats[0], fmt.Sprintf("output format, one of %q", supportedOutputFormats))
+			flags.StringVar(&personID, "id", "", "id of the person who has a DNA match")
+			flags.Float64Var(&cm, "cm", -1, "amount of shared DNA, in centimorgans")
+			living.register(flags)
+
+			flags.Usage = func() {
+				fmt.Fprintf(flags.Output(), `%s < path/to/input
+
+Description:
+	Pipe in some input data, list the people in the tree who could be a DNA
+	match of a person, given the amount of DNA that they share.
+
+	For each person, the closest blood relationship through birth parents is
+	compared with the Shared cM Project reference table, version 4. A person
+	is listed if the amount of DNA is within the expected range for the
+	relationship. The most closely related people are first. Relationships
+	that are not in the table, such as 6th cousins, are not listed.
+
+	The input data is the same as for the relate subcommand.
+
+Examples:
+	# Who could share 850 cM with a person?
+	$ %s -id @I111@ -cm 850 < path/to/data.ged
+`,
+					initUsageLine(name), fullName,
+				)
+				printFlagDefaults(flags)
+			}
+			return flags
+		},
+		Run: func(ctx context.Context) (err error) {
+			if personID == "" || cm < 0 {
+				err = errors.New("Flags -id and -cm are required. Example: -id @I123@ -cm 850")
+				return
+			}
+
+			people, unions, err := readEntities(ctx, os.Stdin, inputFormat)
+			if err != nil {
+				return
+			}
+			if err = living.apply(ctx, people); err != nil {
+				return
+			}
+
+			matches, err := srv.NewGraph(people, unions).MatchSharedCM(ctx, personID, cm)
+			if err != nil {
+				return
+			}
+
+			rows := make([]sharedCMRow, len(matches))
+			for i, match := range matches {
+				rows[i] = sharedCMRow{
+					Person:      simplifyPerson(*match.Person),
+					Description: match.Relationship.R2.Description,
+					SharedCM:    buildSharedCM(match.Expected),
+				}
+			}
+
+			switch outputFormat {
+			case supportedOutputFormats[1]:
+				err = writeJSON(os.Stdout, rows)
+			default:
+				err = renderSharedCMRows(os.Stdout, rows)
+			}
+			return
+		},
+	}
+
+	return &out
+}
+
+type sharedCMRow struct {
+	Person *groupSheetSimplePerson `json:"person"`
+	// Description is how the Person is related to the person with the DNA
+	// match, like "1st cousin".
+	Description string    `json:"description"`
+	SharedCM    *sharedCM `json:"shared_cm"`
+}
+
+func renderSharedCMRows(w io.Writer, rows []sharedCMRow) error {
+	out := table.New().
+		Headers("id", "name", "birth_date", "relationship", "expected_cm").
+		StyleFunc(getTableRowStyle).
+		BorderRow(true).
+		BorderStyle(styleFaint)
+
+	for _, row := range rows {
+		out = out.Row(row.Person.ID, row.Person.Name, row.Person.Birth.Date, row.Description, formatSharedCM(row.SharedCM))
+	}
+
+	_, err := fmt.Fprintln(w, out.Render())
+	return err
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rafaelespinoza/alf"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/srv"
+)
+
+func makeExploreDataDNALines(parentName, name string) alf.Directive {
+	var inputFormat, outputFormat, personID string
+	var living livingFlags
+	supportedInputFormats := []string{"gedcom", "json"}
+	supportedOutputFormats := []string{"", "json"}
+	out := alf.Command{
+		Description: "list the Y-DNA and mtDNA lines of a person, and who carries them",
+		Setup: func(p flag.FlagSet) *flag.FlagSet {
+			fullName := mainName + " " + parentName + " " + name
+			flags := newFlagSet(fullName)
+			flags.StringVar(&inputFormat, "f", supportedInputFormats[0], fmt.Sprintf("input format, one of %q", supportedInputFormats))
+			flags.StringVar(&outputFormat, "output-format", supportedOutputFormats[0], fmt.Sprintf("output format, one of %q", supportedOutputFormats))
+			flags.StringVar(&personID, "id", "", "id of the person")
+			living.register(flags)
+
+			flags.Usage = func() {
+				fmt.Fprintf(flags.Output(), `%s < path/to/input
+
+Description:
+	Pipe in some input data, list the direct paternal and maternal lines of a
+	person, to plan Y-DNA and mtDNA tests.
+
+	The paternal line is the person, their father, their father's father and
+	so on. A father passes his Y chromosome to his sons. So the carriers of
+	the Y-DNA of that line are the males who descend from the earliest known
+	father, only through males.
+
+	The maternal line is the person, their mother, their mother's mother and
+	so on. A mother passes her mtDNA to all of her children, but only her
+	daughters pass it on. So the carriers of the mtDNA of that line are the
+	descendants of the earliest known mother, only through females.
+
+	Only birth parents and children are considered, and the sex of each
+	person should be known. A carrier is only listed if they are presumed to
+	be living, see the flag living-max-age.
+
+	For X-DNA, see the -x-dna flag of the relate subcommand.
+
+	The input data is the same as for the relate subcommand.
+
+Examples:
+	$ %s -id @I111@ < path/to/data.ged
+`,
+					initUsageLine(name), fullName,
+				)
+				printFlagDefaults(flags)
+			}
+			return flags
+		},
+		Run: func(ctx context.Context) (err error) {
+			if personID == "" {
+				err = errors.New("Flag -id is required. Example: -id @I123@")
+				return
+			}
+
+			people, unions, err := readEntities(ctx, os.Stdin, inputFormat)
+			if err != nil {
+				return
+			}
+			if err = living.apply(ctx, people); err != nil {
+				return
+			}
+			graph := srv.NewGraph(people, unions)
+
+			paternal, err := graph.PaternalLine(personID)
+			if err != nil {
+				return
+			}
+			maternal, err := graph.MaternalLine(personID)
+			if err != nil {
+				return
+			}
+			data := dnaLines{Paternal: buildDNALine(paternal), Maternal: buildDNALine(maternal)}
+
+			switch outputFormat {
+			case supportedOutputFormats[1]:
+				err = writeJSON(os.Stdout, data)
+			default:
+				err = renderDNALines(os.Stdout, data)
+			}
+			return
+		},
+	}
+
+	return &out
+}
+
+type (
+	dnaLines struct {
+		Paternal dnaLine `json:"paternal"`
+		Maternal dnaLine `json:"maternal"`
+	}
+	dnaLine struct {
+		Line     []*groupSheetSimplePerson `json:"line"`
+		Carriers []*groupSheetSimplePerson `json:"carriers"`
+	}
+)
+
+func buildDNALine(in srv.DNALine) (out dnaLine) {
+	simplify := func(people []*entity.Person) []*groupSheetSimplePerson {
+		out := make([]*groupSheetSimplePerson, len(people))
+		for i, person := range people {
+			out[i] = simplifyPerson(*person)
+		}
+		return out
+	}
+	out.Line = simplify(in.Line)
+	out.Carriers = simplify(in.Carriers)
+	return
+}
+
+func renderDNALines(w io.Writer, in dnaLines) error {
+	columns := []string{"id", "name", "birth_date", "death_date", "living"}
+	sections := []struct {
+		Title, Description string
+		Data               dnaLine
+	}{
+		{"paternal line (Y-DNA)", "The person, their father, their father's father and so on.", in.Paternal},
+		{"maternal line (mtDNA)", "The person, their mother, their mother's mother and so on.", in.Maternal},
+	}
+
+	parts := make([]string, 0, len(sections))
+	for _, section := range sections {
+		var b strings.Builder
+		b.WriteString(styleBold.Render(section.Title) + "\n")
+		b.WriteString(styleFaint.Render(section.Description) + "\n")
+		b.WriteString(tableizeGroupSheetPeople(columns, section.Data.Line...) + "\n")
+		b.WriteString(styleBold.Render("carriers") + "\n")
+		b.WriteString(styleFaint.Render("Who else has the same DNA, and is presumed to be living?") + "\n")
+		b.WriteString(tableizeGroupSheetPeople(columns, section.Data.Carriers...) + "\n")
+		parts = append(parts, b.String())
+	}
+
+	_, err := fmt.Fprintln(w, lipgloss.JoinHorizontal(lipgloss.Top, parts...))
+	return err
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/rafaelespinoza/alf"
+
+	"github.com/rafaelespinoza/ged/internal/srv"
+)
+
+func makeExploreDataInbreeding(parentName, name string) alf.Directive {
+	var inputFormat, outputFormat string
+	var living livingFlags
+	supportedInputFormats := []string{"gedcom", "json"}
+	supportedOutputFormats := []string{"", "json"}
+	out := alf.Command{
+		Description: "list people whose parents are related by blood",
+		Setup: func(p flag.FlagSet) *flag.FlagSet {
+			fullName := mainName + " " + parentName + " " + name
+			flags := newFlagSet(fullName)
+			flags.StringVar(&inputFormat, "f", supportedInputFormats[0], fmt.Sprintf("input format, one of %q", supportedInputFormats))
+			flags.StringVar(&outputFormat, "output-format", supportedOutputFormats[0], fmt.Sprintf("output format, one of %q", supportedOutputFormats))
+			living.register(flags)
+
+			flags.Usage = func() {
+				fmt.Fprintf(flags.Output(), `%s < path/to/input
+
+Description:
+	Pipe in some input data, list each person with a non-zero inbreeding
+	coefficient, highest first.
+
+	The inbreeding coefficient is the probability that both copies of a gene
+	are identical by descent. It's the kinship coefficient of the parents,
+	like 0.25 for a child of siblings or 0.0625 for a child of 1st cousins.
+	Only birth parents are considered.
+
+	The input data is the same as for the relate subcommand.
+
+Examples:
+	# Using gedcom-formatted data.
+	$ %s < path/to/data.ged
+
+	# Using json-formatted data.
+	$ %s -f json < path/to/data.json
+`,
+					initUsageLine(name), fullName, fullName,
+				)
+				printFlagDefaults(flags)
+			}
+			return flags
+		},
+		Run: func(ctx context.Context) (err error) {
+			people, unions, err := readEntities(ctx, os.Stdin, inputFormat)
+			if err != nil {
+				return
+			}
+			if err = living.apply(ctx, people); err != nil {
+				return
+			}
+
+			graph := srv.NewGraph(people, unions)
+			rows := buildInbreedingRows(graph)
+
+			switch outputFormat {
+			case supportedOutputFormats[1]:
+				err = writeJSON(os.Stdout, rows)
+			default:
+				err = renderInbreedingRows(os.Stdout, rows)
+			}
+			return
+		},
+	}
+
+	return &out
+}
+
+type inbreedingRow struct {
+	Person      *groupSheetSimplePerson   `json:"person"`
+	Parents     []*groupSheetSimplePerson `json:"parents"`
+	Coefficient float64                   `json:"coefficient"`
+}
+
+func buildInbreedingRows(graph *srv.Graph) []inbreedingRow {
+	coefficients := graph.InbreedingCoefficients()
+
+	out := make([]inbreedingRow, 0, len(coefficients))
+	for id, coefficient := range coefficients {
+		person, ok := graph.Person(id)
+		if !ok {
+			continue
+		}
+		row := inbreedingRow{Person: simplifyPerson(*person), Coefficient: coefficient}
+		for _, parent := range graph.Parents(id) {
+			row.Parents = append(row.Parents, simplifyPerson(*parent))
+		}
+		out = append(out, row)
+	}
+
+	slices.SortFunc(out, func(a, b inbreedingRow) int {
+		if c := cmp.Compare(b.Coefficient, a.Coefficient); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Person.ID, b.Person.ID)
+	})
+	return out
+}
+
+func renderInbreedingRows(w io.Writer, rows []inbreedingRow) error {
+	out := table.New().
+		Headers("id", "name", "birth_date", "parents", "coefficient").
+		StyleFunc(getTableRowStyle).
+		BorderRow(true).
+		BorderStyle(styleFaint)
+
+	for _, row := range rows {
+		var parents string
+		for i, parent := range row.Parents {
+			if i > 0 {
+				parents += "\n"
+			}
+			parents += parent.Name
+		}
+		out = out.Row(row.Person.ID, row.Person.Name, row.Person.Birth.Date, parents, formatCoefficient(row.Coefficient))
+	}
+
+	_, err := fmt.Fprintln(w, out.Render())
+	return err
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/rafaelespinoza/alf"
+
+	"github.com/rafaelespinoza/ged/internal/srv"
+)
+
+func makeExploreDataKinship(parentName, name string) alf.Directive {
+	var inputFormat, outputFormat, idList, idsFile string
+	var genetic bool
+	var living livingFlags
+	supportedInputFormats := []string{"gedcom", "json"}
+	supportedOutputFormats := []string{"", "csv", "json"}
+	out := alf.Command{
+		Description: "relate each pair of people in a group",
+		Setup: func(p flag.FlagSet) *flag.FlagSet {
+			fullName := mainName + " " + parentName + " " + name
+			flags := newFlagSet(fullName)
+			flags.StringVar(&inputFormat, "f", supportedInputFormats[0], fmt.Sprintf("input format, one of %q", supportedInputFormats))
+			flags.StringVar(&outputFormat, "output-format", supportedOutputFormats[0], fmt.Sprintf("output format, one of %q", supportedOutputFormats))
+			flags.StringVar(&idList, "ids", "", "comma-separated ids of the people")
+			flags.StringVar(&idsFile, "ids-file", "", "path to a file with the ids of the people")
+			flags.BoolVar(&genetic, "genetic", false, "only find blood relationships through birth parents")
+			living.register(flags)
+
+			flags.Usage = func() {
+				fmt.Fprintf(flags.Output(), `%s < path/to/input
+
+Description:
+	Pipe in some input data, relate each pair of people in a group, such as
+	the DNA testers in a family, or the guests of a reunion.
+
+	The output is a matrix, with a row and a column for each person. The cell
+	at a row and column describes how the person of the row is related to the
+	person of the column, like "1st cousin", and the coefficient of
+	relationship. See the relate subcommand for more about each of those. A
+	cell is empty if the people are not related.
+
+	In a CSV file, the description and the coefficient are in separate
+	columns. So each person has a column named by their id, like "@I1@", and
+	another one for the coefficient, like "@I1@_coefficient".
+
+Choosing people to relate:
+	Select the people with the flag -ids, the flag -ids-file, or both. The
+	file has one or more ids on each line, separated by commas or spaces.
+	Anything on a line after a "#" is a comment. Each person is only in the
+	output once, even if their id is given more than once.
+
+Examples:
+	# Show a table.
+	$ %s -ids @I1@,@I2@,@I3@ < path/to/data.ged
+
+	# Write a CSV file.
+	$ %s -ids-file path/to/testers.txt -output-format csv < path/to/data.ged > kinship.csv
+`,
+					initUsageLine(name), fullName, fullName,
+				)
+				printFlagDefaults(flags)
+			}
+			return flags
+		},
+		Run: func(ctx context.Context) (err error) {
+			ids := splitIDs(idList)
+			if idsFile != "" {
+				var fromFile []string
+				if fromFile, err = readIDsFile(idsFile); err != nil {
+					return
+				}
+				ids = append(ids, fromFile...)
+			}
+			ids = uniqueIDs(ids)
+			if len(ids) < 1 {
+				err = errors.New("Flag -ids or -ids-file is required. Example: -ids @I1@,@I2@,@I3@")
+				return
+			}
+
+			people, unions, err := readEntities(ctx, os.Stdin, inputFormat)
+			if err != nil {
+				return
+			}
+			if err = living.apply(ctx, people); err != nil {
+				return
+			}
+
+			graph := srv.NewGraph(people, unions)
+			relator := graph.Relator()
+			if genetic {
+				relator = graph.GeneticRelator()
+			}
+
+			matrix, err := graph.KinshipMatrix(ctx, relator, ids)
+			if err != nil {
+				return
+			}
+			data := kinshipMatrix{People: make([]*groupSheetSimplePerson, len(ids)), Matrix: make([][]kinship, len(matrix))}
+			for i, id := range ids {
+				person, _ := graph.Person(id)
+				data.People[i] = simplifyPerson(*person)
+			}
+			for i, row := range matrix {
+				data.Matrix[i] = make([]kinship, len(row))
+				for j, cell := range row {
+					data.Matrix[i][j] = kinship{Description: cell.Description, Coefficient: cell.Coefficient}
+				}
+			}
+
+			switch outputFormat {
+			case supportedOutputFormats[1]:
+				err = writeKinshipMatrixCSV(os.Stdout, data)
+			case supportedOutputFormats[2]:
+				err = writeJSON(os.Stdout, data)
+			default:
+				err = renderKinshipMatrix(os.Stdout, data)
+			}
+			return
+		},
+	}
+
+	return &out
+}
+
+type (
+	// kinshipMatrix has a row and a column for each of the People, in the same
+	// order.
+	kinshipMatrix struct {
+		People []*groupSheetSimplePerson `json:"people"`
+		Matrix [][]kinship               `json:"matrix"`
+	}
+	kinship struct {
+		Description string  `json:"description"`
+		Coefficient float64 `json:"coefficient"`
+	}
+)
+
+// splitIDs separates ids by commas or whitespace.
+func splitIDs(in string) []string {
+	return strings.FieldsFunc(in, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+}
+
+// uniqueIDs keeps the first of each id, in order.
+func uniqueIDs(in []string) []string {
+	out := make([]string, 0, len(in))
+	seen := make(map[string]struct{}, len(in))
+	for _, id := range in {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
+
+func readIDsFile(path string) (out []string, err error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		out = append(out, splitIDs(line)...)
+	}
+	err = scanner.Err()
+	return
+}
+
+func writeKinshipMatrixCSV(w io.Writer, in kinshipMatrix) error {
+	out := csv.NewWriter(w)
+
+	header := []string{"id", "name"}
+	for _, person := range in.People {
+		header = append(header, person.ID, person.ID+"_coefficient")
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for i, row := range in.Matrix {
+		record := []string{in.People[i].ID, in.People[i].Name}
+		for _, cell := range row {
+			if cell.Description == "" {
+				record = append(record, "", "")
+				continue
+			}
+			record = append(record, cell.Description, strconv.FormatFloat(cell.Coefficient, 'g', -1, 64))
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func renderKinshipMatrix(w io.Writer, in kinshipMatrix) error {
+	headers := []string{"id", "name"}
+	for _, person := range in.People {
+		headers = append(headers, person.ID)
+	}
+
+	out := table.New().
+		Headers(headers...).
+		StyleFunc(getTableRowStyle).
+		BorderRow(true).
+		BorderStyle(styleFaint)
+
+	for i, row := range in.Matrix {
+		values := []string{in.People[i].ID, in.People[i].Name}
+		for _, cell := range row {
+			if cell.Description == "" {
+				values = append(values, "")
+				continue
+			}
+			values = append(values, cell.Description+"\n"+formatCoefficient(cell.Coefficient))
+		}
+		out = out.Row(values...)
+	}
+
+	_, err := fmt.Fprintln(w, out.Render())
+	return err
+}
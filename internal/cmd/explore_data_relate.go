@@ -15,6 +15,8 @@ import (
 
 func makeExploreDataRelate(parentName, name string) alf.Directive {
 	var inputFormat, outputFormat, p1ID, p2ID string
+	var all, genetic, xDNA bool
+	var living livingFlags
 	supportedInputFormats := []string{"gedcom", "json"}
 	supportedOutputFormats := []string{"", "json"}
 	out := alf.Command{
@@ -26,6 +28,10 @@ func makeExploreDataRelate(parentName, name string) alf.Directive {
 			flags.StringVar(&outputFormat, "output-format", supportedOutputFormats[0], fmt.Sprintf("output format, one of %q", supportedOutputFormats))
 			flags.StringVar(&p1ID, "p1", "", "id of person 1")
 			flags.StringVar(&p2ID, "p2", "", "id of person 2")
+			flags.BoolVar(&all, "all", false, "find every relationship between the people, not only the closest one")
+			flags.BoolVar(&genetic, "genetic", false, "only find blood relationships through birth parents")
+			flags.BoolVar(&xDNA, "x-dna", false, "only find blood relationships along which X-DNA could be inherited")
+			living.register(flags)
 
 			flags.Usage = func() {
 				fmt.Fprintf(flags.Output(), `%s < path/to/input
@@ -33,6 +39,33 @@ func makeExploreDataRelate(parentName, name string) alf.Directive {
 Description:
 	Pipe in some input data, calculate relationship between 2 people.
 
+	If the people only descend from one member of the ancestral couple, such
+	as children of the same father with different mothers, then the
+	relationship is half-blood, like "half sibling" or "half 1st cousin 1x removed".
+
+	If a parent of one person is in a union with the other person, or with a
+	parent of the other person, then the relationship is a step relationship,
+	like "step-mother" or "step-sibling". A union that ended before a person
+	was born, or started after they died, does not make a step relationship.
+
+	The description uses the sex of each person when it's known, like "uncle"
+	or "great grandmother". Otherwise, it's neutral, like "aunt/uncle".
+
+	A child may be linked to a family by adoption or fostering, with the
+	PEDI tag of the GEDCOM data. A relationship through such a link says so,
+	like "adoptive father", "adopted son" or "foster 1st cousin". Set -genetic
+	to only find blood relationships through birth parents, which is what
+	matters for DNA. Then adoptive, foster, step and affinal relationships
+	are ignored.
+
+	Set -x-dna to find if there's a path of X-DNA inheritance between the
+	people. A son only inherits an X chromosome from his mother, while a
+	daughter inherits one from each parent. So a path only goes from a child
+	to their father if the child is female. To be safe, a child of unknown
+	sex is treated like a son, and a parent of unknown sex is not followed
+	unless the child is female. If there is no such path, then the people are
+	unrelated as far as X-DNA goes.
+
 Format of input data:
 	The input data should represent an array of people ([]entity.Person).
 
@@ -40,22 +73,53 @@ Format of input data:
 
 	If the format is "json", then the shape of that data should be:
 		{
-		  "people": []entity.Person{}
+		  "people": []entity.Person{},
+		  "unions": []entity.Union{}
 		}
-	See the parse subcommand to format that JSON.
+	See the parse subcommand to format that JSON. The unions are optional.
+	Each person may have all of the fields output by "parse to-entities",
+	including sex, events, attributes and notes.
 
 Choosing people to relate:
 	You must also select 2 people for which to calculate the relationship by
 	specifying their IDs. Do this with flags -p1, -p2.
 
+Finding every relationship:
+	People may be related in more than one way, such as double first cousins,
+	descendants of an ancestor through more than one line, or cousins who are
+	also in-laws. By default, only the closest relationship is shown. Set -all
+	to show each of them, closest first. The JSON output is then an array.
+
+Coefficients:
+	The output also has Wright's coefficient of relationship between the
+	people, which is the expected fraction of DNA that they share by descent,
+	like 0.5 for siblings or 0.125 for 1st cousins. It's summed over every path
+	through a common ancestor, so it counts all of the relationships by blood.
+	The inbreeding coefficient of each person is the kinship coefficient of
+	their parents. These only consider birth parents, whether or not -genetic
+	is set.
+
+	For a blood relationship through birth parents, the output also has the
+	expected range of shared DNA, in centimorgans (cM), from the Shared cM
+	Project. See the cm subcommand to find relatives that fit a DNA match.
+
 Examples:
 	# Using gedcom-formatted data.
 	$ %s -p1 @I111@ -p2 @I222@ < path/to/data.ged
 
 	# Using json-formatted data.
 	$ %s -f json -p1 @I111@ -p2 @I222@ < path/to/data.json
+
+	# Find every relationship.
+	$ %s -all -p1 @I111@ -p2 @I222@ < path/to/data.ged
+
+	# Only find genetic relationships.
+	$ %s -genetic -p1 @I111@ -p2 @I222@ < path/to/data.ged
+
+	# Find a path of X-DNA inheritance.
+	$ %s -x-dna -p1 @I111@ -p2 @I222@ < path/to/data.ged
 `,
-					initUsageLine(name), fullName, fullName,
+					initUsageLine(name), fullName, fullName, fullName, fullName, fullName,
 				)
 				printFlagDefaults(flags)
 			}
@@ -67,34 +131,62 @@ Examples:
 				return
 			}
 
-			var (
-				people []*entity.Person
-				result entity.MutualRelationship
-			)
-			switch inputFormat {
-			case "json":
-				var data struct{ People []*entity.Person }
-				if err = readJSON(os.Stdin, &data); err != nil {
+			people, unions, err := readEntities(ctx, os.Stdin, inputFormat)
+			if err != nil {
+				return
+			}
+			if err = living.apply(ctx, people); err != nil {
+				return
+			}
+
+			graph := srv.NewGraph(people, unions)
+			relator := graph.Relator()
+			if xDNA {
+				relator = graph.XRelator()
+			} else if genetic {
+				relator = graph.GeneticRelator()
+			}
+
+			coefficients := buildCoefficients(graph, p1ID, p2ID)
+
+			if !all {
+				var result entity.MutualRelationship
+				if result, err = relator.Relate(ctx, p1ID, p2ID); err != nil {
 					return
 				}
-				people = data.People
-			default:
-				if people, _, err = srv.ParseGedcom(ctx, os.Stdin); err != nil {
-					return
+
+				mr := buildMutualRelationship(graph, result)
+				mr.Coefficients = coefficients
+
+				switch outputFormat {
+				case supportedOutputFormats[1]:
+					err = writeJSON(os.Stdout, mr)
+				default:
+					err = renderMutualRelationship(os.Stdout, mr)
 				}
+				return
 			}
 
-			if result, err = srv.NewRelator(people).Relate(ctx, p1ID, p2ID); err != nil {
+			results, err := relator.RelateEveryWay(ctx, p1ID, p2ID)
+			if err != nil {
 				return
 			}
 
-			mr := buildMutualRelationship(result)
+			mrs := make([]mutualRelationship, len(results))
+			for i, result := range results {
+				mrs[i] = buildMutualRelationship(graph, result)
+				mrs[i].Coefficients = coefficients
+			}
 
 			switch outputFormat {
 			case supportedOutputFormats[1]:
-				err = writeJSON(os.Stdout, mr)
+				err = writeJSON(os.Stdout, mrs)
 			default:
-				err = renderMutualRelationship(os.Stdout, mr)
+				for _, mr := range mrs {
+					if err = renderMutualRelationship(os.Stdout, mr); err != nil {
+						return
+					}
+				}
 			}
 
 			return
@@ -106,16 +198,38 @@ Examples:
 
 type (
 	mutualRelationship struct {
-		Person1       *groupSheetSimplePerson   `json:"person_1"`
-		Person2       *groupSheetSimplePerson   `json:"person_2"`
-		Union         []*groupSheetSimplePerson `json:"union"`
-		CommonPerson  *groupSheetSimplePerson   `json:"common_person"`
-		Relationship1 *relationship             `json:"relationship_1"`
-		Relationship2 *relationship             `json:"relationship_2"`
+		Person1      *groupSheetSimplePerson   `json:"person_1"`
+		Person2      *groupSheetSimplePerson   `json:"person_2"`
+		Union        []*groupSheetSimplePerson `json:"union"`
+		CommonPerson *groupSheetSimplePerson   `json:"common_person"`
+		// CommonAncestors is the ancestral couple, or only the CommonPerson.
+		CommonAncestors []*groupSheetSimplePerson `json:"common_ancestors,omitempty"`
+		Relationship1   *relationship             `json:"relationship_1"`
+		Relationship2   *relationship             `json:"relationship_2"`
+		Coefficients    *coefficients             `json:"coefficients"`
+		// SharedCM is the expected range of shared DNA for a blood
+		// relationship, if it's known.
+		SharedCM *sharedCM `json:"shared_cm,omitempty"`
+	}
+	sharedCM struct {
+		Relationship string  `json:"relationship"`
+		Low          float64 `json:"low"`
+		Average      float64 `json:"average"`
+		High         float64 `json:"high"`
+	}
+	// coefficients are genetic measures of the people. They only consider
+	// blood relationships through birth parents.
+	coefficients struct {
+		// Relationship is Wright's coefficient of relationship between the
+		// people, summed over every path through a common ancestor.
+		Relationship float64 `json:"relationship"`
+		Inbreeding1  float64 `json:"inbreeding_1"`
+		Inbreeding2  float64 `json:"inbreeding_2"`
 	}
 	relationship struct {
 		Description        string                    `json:"description"`
 		Type               string                    `json:"type"`
+		Pedigree           string                    `json:"pedigree,omitempty"`
 		GenerationsRemoved int                       `json:"generations_removed"`
 		Path               []*groupSheetSimplePerson `json:"path"`
 		SourceID           string                    `json:"source_id"`
@@ -123,21 +237,37 @@ type (
 	}
 )
 
-func buildMutualRelationship(in entity.MutualRelationship) (out mutualRelationship) {
+// buildMutualRelationship simplifies the people in the relationship. Some of
+// them, such as the partners of a union, are copies that were made before the
+// LivingStatus was inferred, so that is looked up in the graph.
+func buildMutualRelationship(graph *srv.Graph, in entity.MutualRelationship) (out mutualRelationship) {
+	simplify := func(p entity.Person) *groupSheetSimplePerson {
+		if full, ok := graph.Person(p.ID); ok {
+			p.LivingStatus = full.LivingStatus
+		}
+		return simplifyPerson(p)
+	}
+
 	if in.CommonPerson != nil {
-		out.CommonPerson = simplifyPerson(*in.CommonPerson)
+		out.CommonPerson = simplify(*in.CommonPerson)
+	}
+	if expected, ok := srv.ExpectedSharedCM(in); ok {
+		out.SharedCM = buildSharedCM(expected)
+	}
+	for _, person := range in.CommonAncestors {
+		out.CommonAncestors = append(out.CommonAncestors, simplify(*person))
 	}
 
 	if in.Union != nil {
-		var p1, p2 *groupSheetSimplePerson
-		if in.Union.Person1 != nil {
-			p1 = simplifyPerson(*in.Union.Person1)
-		}
-		if in.Union.Person2 != nil {
-			p2 = simplifyPerson(*in.Union.Person2)
+		out.Union = make([]*groupSheetSimplePerson, 0, len(in.Union.Partners))
+		for _, partner := range in.Union.Partners {
+			if partner == nil || partner.Person == nil {
+				continue
+			}
+			person := simplify(*partner.Person)
+			person.Role = describePartnerRole(partner.Role)
+			out.Union = append(out.Union, person)
 		}
-
-		out.Union = []*groupSheetSimplePerson{p1, p2}
 	}
 
 	type Tuple struct {
@@ -149,16 +279,17 @@ func buildMutualRelationship(in entity.MutualRelationship) (out mutualRelationsh
 	for _, tup := range []Tuple{{in.R1, &out.Relationship1, &out.Person1}, {in.R2, &out.Relationship2, &out.Person2}} {
 		path := make([]*groupSheetSimplePerson, len(tup.Src.Path))
 		for j, person := range tup.Src.Path {
-			path[j] = simplifyPerson(person)
+			path[j] = simplify(person)
 
 			if j == 0 {
-				*tup.PersonDest = simplifyPerson(person)
+				*tup.PersonDest = simplify(person)
 			}
 		}
 
 		*tup.RelDest = &relationship{
 			Description:        tup.Src.Description,
 			Type:               tup.Src.Type.String(),
+			Pedigree:           string(tup.Src.Pedigree),
 			GenerationsRemoved: tup.Src.GenerationsRemoved,
 			Path:               path,
 			SourceID:           tup.Src.SourceID,
@@ -169,6 +300,18 @@ func buildMutualRelationship(in entity.MutualRelationship) (out mutualRelationsh
 	return
 }
 
+func buildCoefficients(graph *srv.Graph, p1ID, p2ID string) *coefficients {
+	return &coefficients{
+		Relationship: graph.CoefficientOfRelationship(p1ID, p2ID),
+		Inbreeding1:  graph.InbreedingCoefficient(p1ID),
+		Inbreeding2:  graph.InbreedingCoefficient(p2ID),
+	}
+}
+
+func buildSharedCM(in srv.SharedCM) *sharedCM {
+	return &sharedCM{Relationship: in.Relationship, Low: in.Low, Average: in.Average, High: in.High}
+}
+
 func simplifyPerson(p entity.Person) *groupSheetSimplePerson {
 	var birth, death groupSheetDate
 	if p.Birthdate != nil {
@@ -178,10 +321,12 @@ func simplifyPerson(p entity.Person) *groupSheetSimplePerson {
 		death = groupSheetDate{Date: p.Deathdate.String()}
 	}
 	return &groupSheetSimplePerson{
-		ID:    p.ID,
-		Role:  "",
-		Name:  p.Name.Full(),
-		Birth: birth,
-		Death: death,
+		ID:     p.ID,
+		Role:   "",
+		Name:   p.Name.Full(),
+		Birth:  birth,
+		Death:  death,
+		Age:    formatAge(p.Birthdate, p.Deathdate),
+		Living: string(p.LivingStatus),
 	}
 }
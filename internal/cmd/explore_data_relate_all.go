@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/rafaelespinoza/alf"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/srv"
+)
+
+func makeExploreDataRelateAll(parentName, name string) alf.Directive {
+	var inputFormat, outputFormat, homeID string
+	var genetic bool
+	var living livingFlags
+	supportedInputFormats := []string{"gedcom", "json"}
+	supportedOutputFormats := []string{"", "csv", "json"}
+	out := alf.Command{
+		Description: "relate one person with everyone else",
+		Setup: func(p flag.FlagSet) *flag.FlagSet {
+			fullName := mainName + " " + parentName + " " + name
+			flags := newFlagSet(fullName)
+			flags.StringVar(&inputFormat, "f", supportedInputFormats[0], fmt.Sprintf("input format, one of %q", supportedInputFormats))
+			flags.StringVar(&outputFormat, "output-format", supportedOutputFormats[0], fmt.Sprintf("output format, one of %q", supportedOutputFormats))
+			flags.StringVar(&homeID, "home", "", "id of the person to relate with everyone else")
+			flags.BoolVar(&genetic, "genetic", false, "only find blood relationships through birth parents")
+			living.register(flags)
+
+			flags.Usage = func() {
+				fmt.Fprintf(flags.Output(), `%s < path/to/input
+
+Description:
+	Pipe in some input data, describe how each person is related to the home
+	person. It's like running the relate subcommand for each person, but much
+	faster, because the ancestors of the home person are only found once.
+
+	Each row has the ID and name of a person, how they are related to the
+	home person, like "1st cousin", the generations removed and the common
+	ancestor. The generations removed is negative for an older generation,
+	like -2 for a grandparent. The closest blood relatives are first, then the
+	step relatives and the relatives through marriage. Anyone who is not
+	related is left out.
+
+	A step relative is a step-parent, step-child or step-sibling. A
+	relationship by marriage is only found for a spouse, a spouse of a blood
+	relative, or a blood relative of a spouse. Set -genetic to only find blood
+	relationships through birth parents.
+
+	The input data is the same as for the relate subcommand.
+
+Examples:
+	# Show a table.
+	$ %s -home @I1@ < path/to/data.ged
+
+	# Write a CSV file.
+	$ %s -home @I1@ -output-format csv < path/to/data.ged > relatives.csv
+`,
+					initUsageLine(name), fullName, fullName,
+				)
+				printFlagDefaults(flags)
+			}
+			return flags
+		},
+		Run: func(ctx context.Context) (err error) {
+			if homeID == "" {
+				err = errors.New("Flag -home is required. Should be the ID of a person. Example: -home @I1@")
+				return
+			}
+
+			people, unions, err := readEntities(ctx, os.Stdin, inputFormat)
+			if err != nil {
+				return
+			}
+			if err = living.apply(ctx, people); err != nil {
+				return
+			}
+
+			graph := srv.NewGraph(people, unions)
+			relator := graph.Relator()
+			if genetic {
+				relator = graph.GeneticRelator()
+			}
+
+			results, err := relator.RelateAll(ctx, homeID)
+			if err != nil {
+				return
+			}
+
+			rows := make([]relativeRow, len(results))
+			for i, result := range results {
+				rows[i] = buildRelativeRow(result)
+			}
+
+			switch outputFormat {
+			case supportedOutputFormats[1]:
+				err = writeRelativeRowsCSV(os.Stdout, rows)
+			case supportedOutputFormats[2]:
+				err = writeJSON(os.Stdout, rows)
+			default:
+				err = renderRelativeRows(os.Stdout, rows)
+			}
+			return
+		},
+	}
+
+	return &out
+}
+
+// relativeRow describes how a person is related to the home person.
+type relativeRow struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	GenerationsRemoved int    `json:"generations_removed"`
+	// CommonAncestors is the ancestral couple, or only the common ancestor. It's
+	// empty for a relationship by marriage.
+	CommonAncestors []*groupSheetSimplePerson `json:"common_ancestors"`
+}
+
+func buildRelativeRow(in entity.MutualRelationship) (out relativeRow) {
+	out.ID = in.R2.SourceID
+	if len(in.R2.Path) > 0 {
+		out.Name = in.R2.Path[0].Name.Full()
+	}
+	out.Description = in.R2.Description
+	out.GenerationsRemoved = in.R2.GenerationsRemoved
+
+	out.CommonAncestors = make([]*groupSheetSimplePerson, 0, len(in.CommonAncestors))
+	for _, person := range in.CommonAncestors {
+		out.CommonAncestors = append(out.CommonAncestors, simplifyPerson(*person))
+	}
+	return
+}
+
+// commonAncestorNames joins the names of the common ancestors, like "John and
+// Jane".
+func (r relativeRow) commonAncestorNames() string {
+	names := make([]string, len(r.CommonAncestors))
+	for i, person := range r.CommonAncestors {
+		names[i] = person.Name
+	}
+	return strings.Join(names, " and ")
+}
+
+var relativeRowColumns = []string{"id", "name", "description", "generations_removed", "common_ancestor"}
+
+func (r relativeRow) values() []string {
+	return []string{r.ID, r.Name, r.Description, strconv.Itoa(r.GenerationsRemoved), r.commonAncestorNames()}
+}
+
+func writeRelativeRowsCSV(w io.Writer, rows []relativeRow) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(relativeRowColumns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := out.Write(row.values()); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func renderRelativeRows(w io.Writer, rows []relativeRow) error {
+	out := table.New().
+		Headers(relativeRowColumns...).
+		StyleFunc(getTableRowStyle).
+		BorderRow(true).
+		BorderStyle(styleFaint)
+
+	for _, row := range rows {
+		out = out.Row(row.values()...)
+	}
+
+	_, err := fmt.Fprintln(w, out.Render())
+	return err
+}
@@ -12,17 +12,38 @@ import (
 	"github.com/rafaelespinoza/alf"
 	"github.com/rafaelespinoza/ged/internal/entity"
 	"github.com/rafaelespinoza/ged/internal/gedcom"
+	"github.com/rafaelespinoza/ged/internal/srv"
 )
 
 type viewGroupSheetInputs struct {
-	peopleByID   map[string]*gedcom.IndividualRecord
-	familiesByID map[string]*gedcom.FamilyRecord
-	targetID     string
+	// graph has the people and how they're related. The records are only for
+	// what the graph does not have, the ordinances and the places of unions.
+	graph    *srv.Graph
+	records  *gedcom.Records
+	targetID string
+}
+
+func (in viewGroupSheetInputs) individualRecord(id string) (*gedcom.IndividualRecord, bool) {
+	i := slices.IndexFunc(in.records.Individuals, func(r *gedcom.IndividualRecord) bool { return r.Xref == id })
+	if i < 0 {
+		return nil, false
+	}
+	return in.records.Individuals[i], true
+}
+
+func (in viewGroupSheetInputs) familyRecord(id string) (*gedcom.FamilyRecord, bool) {
+	i := slices.IndexFunc(in.records.Families, func(r *gedcom.FamilyRecord) bool { return r.Xref == id })
+	if i < 0 {
+		return nil, false
+	}
+	return in.records.Families[i], true
 }
 
 func makeExploreDataShow(parentName, name string) alf.Directive {
 	var showParams viewGroupSheetInputs
 	var outputFormat string
+	var estimates estimateDatesFlags
+	var living livingFlags
 	supportedOutputFormats := []string{"", "json"}
 	out := alf.Command{
 		Description: "display transformed GEDCOM data in a group sheet view",
@@ -32,10 +53,20 @@ func makeExploreDataShow(parentName, name string) alf.Directive {
 
 			flags.StringVar(&showParams.targetID, "target-id", "", "GEDCOM Xref to display")
 			flags.StringVar(&outputFormat, "output-format", supportedOutputFormats[0], fmt.Sprintf("output format, one of %q", supportedOutputFormats))
+			estimates.register(flags)
+			living.register(flags)
 			flags.Usage = func() {
 				fmt.Fprintf(flags.Output(), `%s < path/to/input.ged
 
 Description:
+	Display a person, their families, events and ordinances like a group sheet.
+
+	If estimate-dates is true, then a missing birth or death date may be
+	filled in with a range of years estimated from relatives. Those are marked
+	with "(estimated)".
+
+	Each person is also described as living, deceased or unknown. See the flag
+	living-max-age.
 `,
 					initUsageLine(name),
 				)
@@ -43,24 +74,27 @@ Description:
 			}
 			return flags
 		},
-		Run: func(ctx context.Context) error {
+		Run: func(ctx context.Context) (err error) {
 			if showParams.targetID == "" {
 				return errors.New("target-id is required")
 			}
 
-			records, err := gedcom.ReadRecords(ctx, os.Stdin)
+			showParams.records, err = gedcom.ReadRecords(ctx, os.Stdin, args.dateParseOptions)
 			if err != nil {
 				return err
 			}
 
-			showParams.peopleByID = make(map[string]*gedcom.IndividualRecord, len(records.Individuals))
-			for _, individual := range records.Individuals {
-				showParams.peopleByID[individual.Xref] = individual
+			people, unions, err := srv.ConvertRecords(ctx, showParams.records)
+			if err != nil {
+				return err
 			}
-			showParams.familiesByID = make(map[string]*gedcom.FamilyRecord, len(records.Families))
-			for _, fam := range records.Families {
-				showParams.familiesByID[fam.Xref] = fam
+			if err = estimates.apply(ctx, people, unions); err != nil {
+				return err
+			}
+			if err = living.apply(ctx, people); err != nil {
+				return err
 			}
+			showParams.graph = srv.NewGraph(people, unions)
 
 			data, err := buildGroupSheetView(showParams)
 			if err != nil {
@@ -81,10 +115,14 @@ Description:
 }
 
 func buildGroupSheetView(in viewGroupSheetInputs) (*groupSheetView, error) {
-	target, ok := in.peopleByID[in.targetID]
+	target, ok := in.graph.Person(in.targetID)
 	if !ok {
 		return nil, fmt.Errorf("person with ID %q not found", in.targetID)
 	}
+	record, ok := in.individualRecord(in.targetID)
+	if !ok {
+		return nil, fmt.Errorf("individual record with ID %q not found", in.targetID)
+	}
 
 	var (
 		out groupSheetView
@@ -92,49 +130,75 @@ func buildGroupSheetView(in viewGroupSheetInputs) (*groupSheetView, error) {
 	)
 
 	out.Person = buildGroupSheetPerson(target)
-	out.Notes = make([]string, len(target.Notes))
-	out.FamiliesAsChild = make([]groupSheetFamily, len(target.FamiliesAsChild))
-	out.FamiliesAsPartner = make([]groupSheetFamily, len(target.FamiliesAsPartner))
-	out.Events = buildGroupSheetEvents(target.EventLog())
+	unionsAsChild := in.graph.UnionsAsChild(in.targetID)
+	unionsAsPartner := in.graph.UnionsAsPartner(in.targetID)
 
-	for i, note := range target.Notes {
-		out.Notes[i] = note.Payload
-	}
-	for i, famID := range target.FamiliesAsChild {
-		out.FamiliesAsChild[i], err = buildGroupSheetFamily(famID, in.peopleByID, in.familiesByID)
+	out.Notes = append(make([]string, 0, len(target.Notes)), target.Notes...)
+	out.FamiliesAsChild = make([]groupSheetFamily, len(unionsAsChild))
+	out.FamiliesAsPartner = make([]groupSheetFamily, len(unionsAsPartner))
+	out.Events = buildGroupSheetEvents(target.Events, findEvent(target.Events, "Birth"))
+	out.Ordinances = buildGroupSheetOrdinances(record.Ordinances)
+
+	for i, union := range unionsAsChild {
+		out.FamiliesAsChild[i], err = buildGroupSheetFamily(in, union)
 		if err != nil {
 			return nil, fmt.Errorf("could not make families as child: %w", err)
 		}
 	}
-	for i, famID := range target.FamiliesAsPartner {
-		out.FamiliesAsPartner[i], err = buildGroupSheetFamily(famID, in.peopleByID, in.familiesByID)
+	for i, union := range unionsAsPartner {
+		out.FamiliesAsPartner[i], err = buildGroupSheetFamily(in, union)
 		if err != nil {
 			return nil, fmt.Errorf("could not make families as partner: %w", err)
 		}
+
+		// Sealings to a spouse are recorded on the family, but they're also
+		// about the person.
+		fam, _ := in.familyRecord(union.ID)
+		out.Ordinances = append(out.Ordinances, buildGroupSheetOrdinances(fam.Ordinances)...)
 	}
 
 	return &out, nil
 }
 
-func buildGroupSheetPerson(in *gedcom.IndividualRecord) *groupSheetSimplePerson {
-	var name string
-	if len(in.Names) > 0 {
-		name = in.Names[0].String()
+// buildGroupSheetPerson is like simplifyPerson, but with the places of the
+// birth and death. Where a date is unknown, the estimate is used, if any.
+func buildGroupSheetPerson(in *entity.Person) *groupSheetSimplePerson {
+	out := simplifyPerson(*in)
+	if birth := findEvent(in.Events, "Birth"); birth != nil {
+		out.Birth.Place = birth.Place
+	}
+	if death := findEvent(in.Events, "Death"); death != nil {
+		out.Death.Place = death.Place
 	}
-	var birth, death groupSheetDate
-	if len(in.Birth) > 0 {
-		birth = buildGroupSheetDate(in.Birth[0])
+	if out.Birth.Date == "" && in.EstimatedBirthdate != nil {
+		out.Birth.Date = in.EstimatedBirthdate.String()
 	}
-	if len(in.Death) > 0 {
-		death = buildGroupSheetDate(in.Death[0])
+	if out.Death.Date == "" && in.EstimatedDeathdate != nil {
+		out.Death.Date = in.EstimatedDeathdate.String()
 	}
+	return out
+}
 
-	return &groupSheetSimplePerson{
-		ID:    in.Xref,
-		Name:  name,
-		Birth: birth,
-		Death: death,
+// findEvent is the first event of the type, or nil if there is none.
+func findEvent(in []*entity.Event, typ string) *entity.Event {
+	i := slices.IndexFunc(in, func(event *entity.Event) bool { return event.Type == typ })
+	if i < 0 {
+		return nil
+	}
+	return in[i]
+}
+
+// formatAge describes the amount of time from birth until at. It's empty if
+// that cannot be calculated.
+func formatAge(birth, at *entity.Date) string {
+	if birth == nil || at == nil {
+		return ""
+	}
+	age, err := entity.NewDuration(birth, at)
+	if err != nil {
+		return ""
 	}
+	return age.String()
 }
 
 func buildGroupSheetDate(in *gedcom.Event) (out groupSheetDate) {
@@ -149,41 +213,43 @@ func buildGroupSheetDate(in *gedcom.Event) (out groupSheetDate) {
 	return
 }
 
-func buildGroupSheetFamily(famID string, peopleByID map[string]*gedcom.IndividualRecord, familiesByID map[string]*gedcom.FamilyRecord) (out groupSheetFamily, err error) {
-	fam, ok := familiesByID[famID]
+func buildGroupSheetFamily(in viewGroupSheetInputs, union *entity.Union) (out groupSheetFamily, err error) {
+	fam, ok := in.familyRecord(union.ID)
 	if !ok {
-		err = fmt.Errorf("family with ID %q not found", famID)
+		err = fmt.Errorf("family with ID %q not found", union.ID)
 		return
 	}
 
-	parentSurnames := make([]string, len(fam.ParentXrefs))
-	parents := make([]*groupSheetSimplePerson, len(fam.ParentXrefs))
-	for j, parentID := range fam.ParentXrefs {
-		individual, ok := peopleByID[parentID]
+	parentSurnames := make([]string, 0, len(union.Partners))
+	parents := make([]*groupSheetSimplePerson, 0, len(union.Partners))
+	for _, partner := range union.Partners {
+		if partner == nil || partner.Person == nil {
+			continue
+		}
+		person, ok := in.graph.Person(partner.Person.ID)
 		if !ok {
-			err = fmt.Errorf("parent with ID %q not found", parentID)
+			err = fmt.Errorf("parent with ID %q not found", partner.Person.ID)
 			return
 		}
-		parents[j] = buildGroupSheetPerson(individual)
-		parents[j].Role = "parent"
-		if len(individual.Names) > 0 {
-			parentSurnames[j] = individual.Names[0].Surname
-		}
+		parent := buildGroupSheetPerson(person)
+		parent.Role = describePartnerRole(partner.Role)
+		parents = append(parents, parent)
+		parentSurnames = append(parentSurnames, person.Name.Surname)
 	}
 
-	children := make([]*groupSheetSimplePerson, 0, len(fam.ChildXrefs))
-	for _, childID := range fam.ChildXrefs {
-		individual, ok := peopleByID[childID]
+	children := make([]*groupSheetSimplePerson, 0, len(union.Children))
+	for _, child := range union.Children {
+		person, ok := in.graph.Person(child.ID)
 		if !ok {
-			err = fmt.Errorf("child with ID %q not found", childID)
+			err = fmt.Errorf("child with ID %q not found", child.ID)
 			return
 		}
-		children = append(children, buildGroupSheetPerson(individual))
+		children = append(children, buildGroupSheetPerson(person))
 		children[len(children)-1].Role = "child"
 	}
 
 	out = groupSheetFamily{
-		ID:         famID,
+		ID:         union.ID,
 		Title:      "The " + strings.Join(parentSurnames, " ") + " family",
 		MarriedAt:  buildGroupSheetDate(fam.MarriedAt),
 		DivorcedAt: buildGroupSheetDate(fam.DivorcedAt),
@@ -194,6 +260,19 @@ func buildGroupSheetFamily(famID string, peopleByID map[string]*gedcom.Individua
 	return
 }
 
+// describePartnerRole is a label for the role of a partner in a union. Any
+// number of partners is possible, so there is not a "first" or "second".
+func describePartnerRole(role entity.PartnerRole) string {
+	switch role {
+	case entity.Husband:
+		return "husband"
+	case entity.Wife:
+		return "wife"
+	default:
+		return "partner"
+	}
+}
+
 func buildNotes(in []*gedcom.Note) (out []string) {
 	out = make([]string, len(in))
 	for i, note := range in {
@@ -202,13 +281,41 @@ func buildNotes(in []*gedcom.Note) (out []string) {
 	return
 }
 
-func buildGroupSheetEvents(in []*gedcom.Event) (out []*groupSheetEvent) {
+func buildGroupSheetEvents(in []*entity.Event, birth *entity.Event) (out []*groupSheetEvent) {
+	var birthdate *entity.Date
+	if birth != nil {
+		birthdate = birth.Date
+	}
 	out = make([]*groupSheetEvent, len(in))
 	for i, ev := range in {
+		var date entity.Date // an unknown date is shown as "?".
+		if ev.Date != nil {
+			date = *ev.Date
+		}
 		out[i] = &groupSheetEvent{
-			Date:  buildGroupSheetDate(ev),
+			Date:  groupSheetDate{Date: date.String(), Place: ev.Place},
 			Type:  ev.Type,
-			Notes: buildNotes(ev.Notes),
+			Notes: append(make([]string, 0, len(ev.Notes)), ev.Notes...),
+		}
+		if ev != birth {
+			out[i].Age = formatAge(birthdate, ev.Date)
+		}
+	}
+	return
+}
+
+func buildGroupSheetOrdinances(in []*gedcom.Ordinance) (out []*groupSheetOrdinance) {
+	out = make([]*groupSheetOrdinance, len(in))
+	for i, ord := range in {
+		date, _ := entity.NewDate(ord.Date, ord.DateRange)
+		out[i] = &groupSheetOrdinance{
+			Date:   groupSheetDate{Date: date.String(), Place: ord.Place},
+			Type:   ord.Type,
+			Temple: ord.Temple,
+			Notes:  buildNotes(ord.Notes),
+		}
+		if ord.Status != nil {
+			out[i].Status = string(ord.Status.Status)
 		}
 	}
 	return
@@ -14,12 +14,16 @@ import (
 )
 
 func makeParse(name string) alf.Directive {
+	var toEntitiesEstimates estimateDatesFlags
+	var toEntitiesLiving, toLinesLiving livingFlags
 	toEntities := alf.Command{
 		Description: "transform data to application entity types",
 		Setup: func(_ flag.FlagSet) *flag.FlagSet {
 			subName := "to-entities"
 			fullName := mainName + " " + subName
 			flags := newFlagSet(fullName)
+			toEntitiesEstimates.register(flags)
+			toEntitiesLiving.register(flags)
 
 			flags.Usage = func() {
 				fmt.Fprintf(flags.Output(), `%s < path/to/input
@@ -34,6 +38,28 @@ Description:
 		  "people": []entity.Person{},
 		  "unions": []entity.Union{}
 		}
+
+	Besides the name, dates and relatives, each person has the fields "Sex",
+	"Events", "Attributes" and "Notes". Events are in chronological order and
+	have a type, such as "Birth" or "Residence", with a date, place and notes.
+	Attributes are characteristics, such as an occupation or religion.
+
+	Each date also has the field "EDTF", which is the date in the Extended
+	Date/Time Format, such as "1850~" or "[1850..1860]". It's for exchanging
+	data with archival systems. When this output is read back in, such as by
+	"explore-data relate", a date with only the EDTF field is interpreted too.
+
+	With the flag estimate-dates, a person without a birth or death date may
+	have the fields "EstimatedBirthdate" or "EstimatedDeathdate". These are
+	ranges of years inferred from the dates of their parents, partners,
+	children and unions. Every estimate has the EST qualifier and the phrase
+	"estimated". The known dates are never replaced.
+
+	Each person also has the field "LivingStatus", one of "living",
+	"deceased" or "unknown". A person is deceased if there is a death or
+	burial, or if they would be older than living-max-age by now. They're
+	presumed to be living if they were born within that many years. The dates
+	of relatives help when the birth date is not known.
 `,
 					initUsageLine(subName),
 				)
@@ -42,10 +68,16 @@ Description:
 			return flags
 		},
 		Run: func(ctx context.Context) error {
-			people, unions, err := srv.ParseGedcom(context.Background(), os.Stdin)
+			people, unions, err := srv.ParseGedcom(ctx, os.Stdin, args.dateParseOptions)
 			if err != nil {
 				return err
 			}
+			if err = toEntitiesEstimates.apply(ctx, people, unions); err != nil {
+				return err
+			}
+			if err = toEntitiesLiving.apply(ctx, people); err != nil {
+				return err
+			}
 
 			return writeJSON(os.Stdout, map[string]any{"people": people, "unions": unions})
 		},
@@ -57,6 +89,7 @@ Description:
 			subName := "to-lines"
 			fullName := mainName + " " + subName
 			flags := newFlagSet(fullName)
+			toLinesLiving.register(flags)
 
 			flags.Usage = func() {
 				fmt.Fprintf(flags.Output(), `%s < path/to/input
@@ -67,7 +100,8 @@ Description:
 
 	The output shape is one individual record per line. Within each line, each
 	field is delimited by one ASCII TAB (%q, 0x%x). If a field value is empty,
-	then that key-value pair is omitted.
+	then that key-value pair is omitted. The Status field tells if the person
+	is living, deceased or unknown, see the flag living-max-age.
 
 	Example output lines:
 
@@ -76,18 +110,21 @@ Description:
 `,
 					initUsageLine(subName),
 					fzfLineFieldSeparator, fzfLineFieldSeparator,
-					strings.Join([]string{"@I123@", "Name:Full Name of Person", "Birth:2006-01-02", "Death:2038-01-17", "Parent:Name Of Parent1", "Parent:Name of Parent2"}, fzfLineFieldSeparator),
-					strings.Join([]string{"@I234@", "Name:Full Name of Person", "Birth:2006-01-02", "Parent:Name Of Parent"}, fzfLineFieldSeparator),
+					strings.Join([]string{"@I123@", "Name:Full Name of Person", "Birth:2006-01-02", "Death:2038-01-17", "Status:deceased", "Parent:Name Of Parent1", "Parent:Name of Parent2"}, fzfLineFieldSeparator),
+					strings.Join([]string{"@I234@", "Name:Full Name of Person", "Birth:2006-01-02", "Status:living", "Parent:Name Of Parent"}, fzfLineFieldSeparator),
 				)
 				printFlagDefaults(flags)
 			}
 			return flags
 		},
 		Run: func(ctx context.Context) error {
-			people, _, err := srv.ParseGedcom(context.Background(), os.Stdin)
+			people, _, err := srv.ParseGedcom(ctx, os.Stdin, args.dateParseOptions)
 			if err != nil {
 				return err
 			}
+			if err = toLinesLiving.apply(ctx, people); err != nil {
+				return err
+			}
 			for _, line := range makeFZFPeople(fzfLineFieldSeparator, people) {
 				if _, err = fmt.Println(line); err != nil {
 					return err
@@ -125,7 +162,7 @@ Description:
 			return flags
 		},
 		Run: func(ctx context.Context) error {
-			records, err := gedcom.ReadRecords(ctx, os.Stdin)
+			records, err := gedcom.ReadRecords(ctx, os.Stdin, args.dateParseOptions)
 			if err != nil {
 				return err
 			}
@@ -134,12 +171,89 @@ Description:
 		},
 	}
 
+	var normalizeDatesOutputFormat string
+	normalizeDates := alf.Command{
+		Description: "list DATE values that are not canonical, with replacements",
+		Setup: func(_ flag.FlagSet) *flag.FlagSet {
+			subName := "normalize-dates"
+			fullName := mainName + " " + subName
+			flags := newFlagSet(fullName)
+			supportedOutputFormats := []string{"", "json"}
+			flags.StringVar(&normalizeDatesOutputFormat, "output-format", supportedOutputFormats[0], fmt.Sprintf("output format, one of %q", supportedOutputFormats))
+
+			flags.Usage = func() {
+				fmt.Fprintf(flags.Output(), `%s < path/to/input
+
+Description:
+	Pipe in some GEDCOM data, find every DATE value that is not in the canonical
+	GEDCOM7 format and print a suggested replacement. This could help clean up
+	dates from vendors who are lax about the format, such as "Abt. March 3rd 1890"
+	or "bef 1800".
+
+	Some information does not fit into a canonical DATE value, such as text that
+	cannot be interpreted as a date at all. In that case, there's also a suggested
+	value for the PHRASE substructure of the DATE.
+
+	A DATE like "1731/1732" is ambiguous. It could be an interval of two years,
+	or a dual year that's better written as "1731/32". There's no replacement
+	for it, it's only flagged so that a person can decide.
+
+	By default, the output is one DATE per line. Within each line, each field is
+	delimited by one ASCII TAB (%q, 0x%x). The fields are: the record Xref, the
+	path to the DATE within the record, the original value, the replacement. If
+	there's a suggested PHRASE, then it's the next field. An ambiguous DATE has
+	the field AMBIGUOUS last. Example output lines:
+
+	%s
+	%s
+	%s
+
+	If the output format is "json", then the output shape is:
+		[]srv.DateNormalization{}
+`,
+					initUsageLine(subName),
+					fzfLineFieldSeparator, fzfLineFieldSeparator,
+					strings.Join([]string{"@I123@", "INDI.BIRT.DATE", "Abt. March 3rd 1890", "ABT 3 MAR 1890"}, fzfLineFieldSeparator),
+					strings.Join([]string{"@I234@", "INDI.DEAT.DATE", "unknown", "", "PHRASE:unknown"}, fzfLineFieldSeparator),
+					strings.Join([]string{"@F345@", "FAM.MARR.DATE", "1731/1732", "", "AMBIGUOUS"}, fzfLineFieldSeparator),
+				)
+				printFlagDefaults(flags)
+			}
+			return flags
+		},
+		Run: func(ctx context.Context) error {
+			results, err := srv.NormalizeDates(ctx, os.Stdin, args.dateParseOptions)
+			if err != nil {
+				return err
+			}
+
+			if normalizeDatesOutputFormat == "json" {
+				return writeJSON(os.Stdout, results)
+			}
+
+			for _, result := range results {
+				line := []string{result.RecordXref, result.Path, result.Original, result.Replacement}
+				if result.Phrase != "" {
+					line = append(line, "PHRASE:"+result.Phrase)
+				}
+				if result.Ambiguous {
+					line = append(line, "AMBIGUOUS")
+				}
+				if _, err = fmt.Println(strings.Join(line, fzfLineFieldSeparator)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
 	out := alf.Delegator{
 		Description: "interpret GEDCOM data, transform it, write to STDOUT",
 		Subs: map[string]alf.Directive{
-			"to-entities": &toEntities,
-			"to-lines":    &toLines,
-			"to-records":  &toRecords,
+			"normalize-dates": &normalizeDates,
+			"to-entities":     &toEntities,
+			"to-lines":        &toLines,
+			"to-records":      &toRecords,
 		},
 		Flags: newFlagSet(mainName),
 	}
@@ -174,7 +288,7 @@ func makeFZFPeople(fieldDelimiter string, people []*entity.Person) (out []string
 
 	for i, person := range people {
 		// Set cap to maximum number of non-empty fields you might have (assuming 2 parents).
-		line = make([]string, 0, 6)
+		line = make([]string, 0, 7)
 
 		line = append(line, person.ID)
 		line = append(line, "Name:"+person.Name.Full())
@@ -184,6 +298,9 @@ func makeFZFPeople(fieldDelimiter string, people []*entity.Person) (out []string
 		if d := formatDate(person.Deathdate); d != nil {
 			line = append(line, "Death:"+*d)
 		}
+		if person.LivingStatus != "" {
+			line = append(line, "Status:"+string(person.LivingStatus))
+		}
 		for _, parent := range person.Parents {
 			line = append(line, "Parent:"+parent.Name.Full())
 		}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"encoding/json"
 	"errors"
 
 	"github.com/rafaelespinoza/ged/internal/entity/date"
@@ -17,6 +18,11 @@ import (
 type Date struct {
 	*date.Date
 	*date.Range
+	// EDTF is the Date or Range in the Extended Date/Time Format, for
+	// exchanging data with systems that use it. It's empty if the value cannot
+	// be expressed in EDTF. When decoding JSON, it's interpreted if there is
+	// no Date or Range.
+	EDTF string `json:",omitempty"`
 }
 
 func NewDate(d *date.Date, r *date.Range) (out *Date, err error) {
@@ -24,10 +30,33 @@ func NewDate(d *date.Date, r *date.Range) (out *Date, err error) {
 		err = errors.New("invalid NewDate, Date and Range cannot both be non-empty")
 	} else {
 		out = &Date{Date: d, Range: r}
+		if d != nil {
+			out.EDTF = d.EDTF()
+		} else if r != nil {
+			out.EDTF = r.EDTF()
+		}
 	}
 	return
 }
 
+func (d *Date) UnmarshalJSON(data []byte) error {
+	// This type alias does not have the UnmarshalJSON method, so the default
+	// decoding is used.
+	type plain Date
+	var out plain
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	*d = Date(out)
+
+	if d.Date != nil || d.Range != nil || d.EDTF == "" {
+		return nil
+	}
+	var err error
+	d.Date, d.Range, err = date.ParseEDTF(d.EDTF)
+	return err
+}
+
 func (d Date) String() string {
 	var out string
 
@@ -41,9 +70,47 @@ func (d Date) String() string {
 		} else if d.Range.Hi != nil {
 			out = "<= " + d.Range.Hi.Display
 		}
+
+		if d.Range.Phrase != "" {
+			out += " (" + d.Range.Phrase + ")"
+		}
 	} else {
 		out = "?"
 	}
 
 	return out
 }
+
+// value is whichever of the Date or Range is non-empty. It's nil if both are
+// empty.
+func (d *Date) value() date.Value {
+	if d == nil {
+		return nil
+	} else if d.Date != nil {
+		return d.Date
+	} else if d.Range != nil {
+		return d.Range
+	}
+	return nil
+}
+
+// NewDuration calculates the amount of time from one Date to another, such as
+// the age of a person at an event. See date.NewDuration for details.
+func NewDuration(from, to *Date) (out date.Duration, err error) {
+	fromVal, toVal := from.value(), to.value()
+	if fromVal == nil || toVal == nil {
+		err = errors.New("invalid NewDuration, both inputs must have a Date or a Range")
+		return
+	}
+	return date.NewDuration(fromVal, toVal)
+}
+
+// Years is the earliest and latest possible years of the Date or Range. See
+// date.Years for details. Both are unknown if the Date is empty.
+func (d *Date) Years() (lo, hi int, hasLo, hasHi bool) {
+	val := d.value()
+	if val == nil {
+		return
+	}
+	return date.Years(val)
+}
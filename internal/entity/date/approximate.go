@@ -21,16 +21,17 @@ var approximateTokens = []string{
 	"ABOUT", // not officially GEDCOM7, but is accepted to mean the same thing as "ABT".
 }
 
-func parseDateWithApproximationToken(layout, in string) (out *Date, err error) {
-	_, touchedIn, originallyApproximation, err := originallyApproximate(in)
+func parseDateWithApproximationToken(in string) (out *Date, err error) {
+	token, touchedIn, originallyApproximation, err := originallyApproximate(in)
 	if err != nil {
 		return nil, err
 	}
 
-	if out, err = parse(layout, touchedIn); err != nil {
+	if out, err = parseDateWithoutApproximationToken(touchedIn); err != nil {
 		return nil, err
 	}
 	out.Payload = in
+	out.Qualifier = token
 
 	if originallyApproximation {
 		out.Approximate = true
@@ -41,7 +42,8 @@ func parseDateWithApproximationToken(layout, in string) (out *Date, err error) {
 
 // originallyApproximate determines if the input in specifically had a word,
 // such as "ABT", "CAL", or "EST", to indicate that the date is an
-// approximation of a date.
+// approximation of a date. The output token is the GEDCOM7 version of that
+// word, such as "ABT" for "Abt." or "About".
 func originallyApproximate(in string) (token, date string, approx bool, err error) {
 	uin := strings.ToUpper(in)
 
@@ -50,12 +52,16 @@ func originallyApproximate(in string) (token, date string, approx bool, err erro
 		// of data cased like "This", or "this". Accept any casing here so that
 		// the application can work with that data.
 		if strings.HasPrefix(uin, prefix) {
-			token, date, approx = strings.Cut(in, " ")
+			_, date, approx = strings.Cut(in, " ")
 			if len(date) < 1 {
 				err = fmt.Errorf("malformatted approximate date %q", in)
 				return
 			}
 			if approx {
+				token = prefix
+				if token == "ABOUT" {
+					token = "ABT"
+				}
 				return
 			}
 		}
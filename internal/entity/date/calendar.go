@@ -0,0 +1,378 @@
+package date
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar is a system for naming days. In the GEDCOM7 spec, the ABNF grammar
+// for a calendar is:
+//
+//	calendar = %s"GREGORIAN" / %s"JULIAN" / %s"FRENCH_R" / %s"HEBREW" / extTag
+//
+// Older GEDCOM data, such as v5.5.1, would use an escape sequence instead. So
+// these are also accepted:
+//
+//	@#DGREGORIAN@
+//	@#DJULIAN@
+//	@#DHEBREW@
+//	@#DFRENCH R@
+//
+// The zero value is the Gregorian calendar, which is also what's assumed when
+// the input does not specify a calendar.
+type Calendar int
+
+const (
+	Gregorian Calendar = iota
+	Julian
+	Hebrew
+	FrenchRepublican
+)
+
+var calendarNames = []struct{ Token, Display string }{
+	{"GREGORIAN", "Gregorian"},
+	{"JULIAN", "Julian"},
+	{"HEBREW", "Hebrew"},
+	{"FRENCH_R", "French Republican"},
+}
+
+// String returns the GEDCOM7 name for the calendar.
+func (c Calendar) String() string {
+	if c < 0 || int(c) >= len(calendarNames) {
+		return ""
+	}
+	return calendarNames[c].Token
+}
+
+func (c Calendar) displayName() string {
+	if c < 0 || int(c) >= len(calendarNames) {
+		return ""
+	}
+	return calendarNames[c].Display
+}
+
+// splitCalendar detects a leading calendar token in the input and returns the
+// rest of the input. If there is no calendar token, then the calendar is
+// Gregorian and the rest is the original input.
+func splitCalendar(in string) (cal Calendar, rest string) {
+	var token, after string
+	if strings.HasPrefix(in, "@#D") {
+		// An escape sequence may have a space within it, as in "@#DFRENCH R@".
+		end := strings.Index(in[3:], "@")
+		if end < 0 {
+			return Gregorian, in
+		}
+		token, after = in[3:3+end], in[3+end+1:]
+	} else {
+		var found bool
+		if token, after, found = strings.Cut(in, " "); !found {
+			return Gregorian, in
+		}
+	}
+
+	// Like approximation tokens, accept any casing for compatibility with
+	// vendors that do not follow the spec.
+	utoken := strings.ReplaceAll(strings.ToUpper(token), " ", "_")
+
+	for i, name := range calendarNames {
+		if utoken == name.Token {
+			return Calendar(i), strings.TrimSpace(after)
+		}
+	}
+
+	return Gregorian, in
+}
+
+// hasEpochs reports whether or not a year in the calendar may be before the
+// common era, or may be a dual year.
+func (c Calendar) hasEpochs() bool { return c == Gregorian || c == Julian }
+
+// monthsInYear is the maximum number of months in any year of the calendar.
+func (c Calendar) monthsInYear() int {
+	switch c {
+	case Hebrew, FrenchRepublican:
+		return 13
+	default:
+		return 12
+	}
+}
+
+// daysInMonth is the number of days for month m of year y in the calendar. It
+// is 0 if the month does not exist in that year.
+func (c Calendar) daysInMonth(y int, m time.Month) int {
+	if m < 1 || int(m) > c.monthsInYear() {
+		return 0
+	}
+
+	switch c {
+	case Hebrew:
+		return hebrewDaysInMonth(y, m)
+	case FrenchRepublican:
+		return frenchDaysInMonth(y, m)
+	}
+
+	switch m {
+	case time.February:
+		if c.isLeapYear(y) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	default:
+		return 31
+	}
+}
+
+func (c Calendar) isLeapYear(y int) bool {
+	switch c {
+	case Julian:
+		return y%4 == 0
+	case Hebrew:
+		return hebrewIsLeapYear(y)
+	case FrenchRepublican:
+		return frenchIsLeapYear(y)
+	default:
+		return y%4 == 0 && (y%100 != 0 || y%400 == 0)
+	}
+}
+
+// calendarMonth is the vocabulary for one month of a calendar.
+type calendarMonth struct {
+	// Token is the abbreviation from the GEDCOM7 spec.
+	Token string
+	// Names are other accepted ways to write the month, in upper case.
+	Names []string
+}
+
+var gregorianMonths = func() []calendarMonth {
+	out := make([]calendarMonth, 12)
+	for m := time.January; m <= time.December; m++ {
+		full := strings.ToUpper(m.String())
+		out[m-1] = calendarMonth{Token: full[:3], Names: []string{full}}
+	}
+	return out
+}()
+
+func (c Calendar) months() []calendarMonth {
+	switch c {
+	case Hebrew:
+		return hebrewMonths
+	case FrenchRepublican:
+		return frenchMonths
+	default:
+		return gregorianMonths
+	}
+}
+
+// parseMonth interprets in as a month name for the calendar. The Gregorian and
+// Julian calendars use the same month names. Abbreviated or full month names
+// are accepted in any casing.
+func (c Calendar) parseMonth(in string) (time.Month, bool) {
+	uin := strings.ToUpper(in)
+	for i, month := range c.months() {
+		if uin == month.Token {
+			return time.Month(i + 1), true
+		}
+		for _, name := range month.Names {
+			if uin == name {
+				return time.Month(i + 1), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// monthToken is the GEDCOM7 abbreviation of month m in the calendar.
+func (c Calendar) monthToken(m time.Month) string {
+	months := c.months()
+	if m < 1 || int(m) > len(months) {
+		return ""
+	}
+	return months[m-1].Token
+}
+
+// parseYear interprets in as a year number. The French Republican calendar
+// traditionally uses Roman numerals for the year, so those are accepted too.
+func (c Calendar) parseYear(in string) (int, error) {
+	out, err := strconv.Atoi(in)
+	if err != nil && c == FrenchRepublican {
+		out, err = parseRomanNumeral(in)
+	}
+	return out, err
+}
+
+// parseCalendarDate interprets in, which should not have any approximation,
+// calendar, epoch tokens or dual years, as a date in the calendar of out. It's
+// a simpler alternative to parsing with the time package, which only knows
+// about the Gregorian calendar, and only 4-digit years. The accepted shape is:
+//
+//	[[day D] month D] year
+//
+// The fields Calendar, BCE, DualYear of out should already be set, because
+// they affect the validation of the month and day.
+func parseCalendarDate(out *Date, in string) (err error) {
+	fields := strings.Fields(in)
+	c := out.Calendar
+
+	var yearField, monthField, dayField string
+	switch len(fields) {
+	case 1:
+		yearField = fields[0]
+	case 2:
+		monthField, yearField = fields[0], fields[1]
+	case 3:
+		dayField, monthField, yearField = fields[0], fields[1], fields[2]
+	default:
+		return fmt.Errorf("invalid %s date %q", c.displayName(), in)
+	}
+
+	if out.Year, err = c.parseYear(yearField); err != nil || out.Year < 1 {
+		return fmt.Errorf("invalid year in %s date %q", c.displayName(), in)
+	}
+
+	if monthField != "" {
+		var ok bool
+		if out.Month, ok = c.parseMonth(monthField); !ok || c.daysInMonth(out.year(), out.Month) < 1 {
+			return fmt.Errorf("invalid month in %s date %q", c.displayName(), in)
+		}
+	}
+
+	if dayField != "" {
+		out.Day, err = strconv.Atoi(dayField)
+		if err != nil || out.Day < 1 || out.Day > c.daysInMonth(out.year(), out.Month) {
+			return fmt.Errorf("invalid day in %s date %q", c.displayName(), in)
+		}
+	}
+
+	if out.Month == 0 || out.Day == 0 {
+		out.Approximate = true
+	}
+	return nil
+}
+
+// toDayNumber converts a fully-specified date in the calendar c to a Julian
+// Day Number, which is a count of days that is independent of any calendar.
+// Dates from different calendars may be compared by their day numbers.
+func (c Calendar) toDayNumber(y int, m time.Month, d int) int {
+	switch c {
+	case Hebrew:
+		return hebrewToDayNumber(y, m, d)
+	case FrenchRepublican:
+		return frenchToDayNumber(y, m, d)
+	}
+
+	// The algorithm is from "Calendrical Calculations" by Dershowitz and
+	// Reingold, and treats March as the first month so that leap days are at
+	// the end of the year.
+	a := (14 - int(m)) / 12
+	yy := y + 4800 - a
+	mm := int(m) + 12*a - 3
+
+	if c == Julian {
+		return d + (153*mm+2)/5 + 365*yy + yy/4 - 32083
+	}
+	return d + (153*mm+2)/5 + 365*yy + yy/4 - yy/100 + yy/400 - 32045
+}
+
+// fromDayNumber is the inverse of toDayNumber.
+func (c Calendar) fromDayNumber(jdn int) (y int, m time.Month, d int) {
+	switch c {
+	case Hebrew:
+		return hebrewFromDayNumber(jdn)
+	case FrenchRepublican:
+		return frenchFromDayNumber(jdn)
+	}
+
+	var b, cc int
+	if c == Julian {
+		b = 0
+		cc = jdn + 32082
+	} else {
+		a := jdn + 32044
+		b = (4*a + 3) / 146097
+		cc = a - (146097*b)/4
+	}
+	dd := (4*cc + 3) / 1461
+	e := cc - (1461*dd)/4
+	mm := (5*e + 2) / 153
+
+	d = e - (153*mm+2)/5 + 1
+	m = time.Month(mm + 3 - 12*(mm/10))
+	y = 100*b + dd - 4800 + mm/10
+	return
+}
+
+// dayBounds is the earliest and latest day numbers that the Date could
+// represent. If the Date is fully specified, then lo and hi are the same
+// value. Otherwise, they span the month or the year.
+func (d *Date) dayBounds() (lo, hi int) {
+	c, y := d.Calendar, d.year()
+	if d.Month == 0 {
+		lo = c.toDayNumber(y, 1, 1)
+		hi = c.toDayNumber(y+1, 1, 1) - 1
+		return
+	}
+	if d.Day == 0 {
+		lo = c.toDayNumber(y, d.Month, 1)
+		hi = c.toDayNumber(y, d.Month, c.daysInMonth(y, d.Month))
+		return
+	}
+	lo = c.toDayNumber(y, d.Month, d.Day)
+	hi = lo
+	return
+}
+
+var errImpreciseDate = errors.New("date must have a year, month and day")
+
+// In converts the Date to the calendar c. The Date must be fully specified,
+// that is, the Year, Month, Day fields must be non-zero. The output keeps the
+// Approximate field but not the Payload, because the Payload describes the
+// original input. A dual year is converted by its new style year.
+func (d *Date) In(c Calendar) (*Date, error) {
+	if d.Month == 0 || d.Day == 0 {
+		return nil, errImpreciseDate
+	}
+	if c == d.Calendar {
+		out := *d
+		return &out, nil
+	}
+
+	y, m, day := c.fromDayNumber(d.Calendar.toDayNumber(d.year(), d.Month, d.Day))
+	out := &Date{Calendar: c, Year: y, Month: m, Day: day, Approximate: d.Approximate}
+	if y < 1 && c.hasEpochs() {
+		out.Year, out.BCE = 1-y, true
+	} else if y < 1 {
+		return nil, fmt.Errorf("date %s is before the start of the %s calendar", d.Display, c.displayName())
+	}
+	out.setDisplay()
+	return out, nil
+}
+
+// parseRomanNumeral interprets in as a Roman numeral, such as "XIV".
+func parseRomanNumeral(in string) (out int, err error) {
+	values := map[rune]int{'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000}
+
+	uin := strings.ToUpper(in)
+	if uin == "" {
+		return 0, fmt.Errorf("invalid roman numeral %q", in)
+	}
+
+	var prev int
+	for i := len(uin) - 1; i >= 0; i-- {
+		val, ok := values[rune(uin[i])]
+		if !ok {
+			return 0, fmt.Errorf("invalid roman numeral %q", in)
+		}
+		if val < prev {
+			out -= val
+		} else {
+			out += val
+			prev = val
+		}
+	}
+	return out, nil
+}
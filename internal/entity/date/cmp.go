@@ -1,5 +1,7 @@
 package date
 
+import "cmp"
+
 // CmpDates compares two *Dates. It returns
 //
 //	-1 if a < b.
@@ -8,12 +10,36 @@ package date
 //
 // Date fields compared between a and b are Year, Month, Day. A zero value in
 // any of those fields indicates that the date is approximated, thus a more
-// approximate date is considered less than a more precise date.
+// approximate date is considered less than a more precise date. A year before
+// the common era is less than any year after it. A dual year, such as 1731/32,
+// is compared by its new style year, 1732.
+//
+// If a and b are from different calendars, then they are converted to day
+// numbers, which are independent of any calendar. The earliest possible days
+// are compared first, followed by the latest possible days.
+//
+// A phrase-only Date is greater than any Date with a Year, because there's no
+// telling when it is.
+//
+// CmpDates does not account for uncertainty. To tell if one Date is definitely
+// before another, use Compare. To sort dates by their possible days, use
+// CmpValues.
 func CmpDates(a, b *Date) int {
-	if a.Year < b.Year {
-		return -1
-	} else if a.Year > b.Year {
-		return 1
+	if a.PhraseOnly() || b.PhraseOnly() {
+		return cmpBools(a.PhraseOnly(), b.PhraseOnly())
+	}
+
+	if a.Calendar != b.Calendar {
+		aLo, aHi := a.dayBounds()
+		bLo, bHi := b.dayBounds()
+		if c := cmp.Compare(aLo, bLo); c != 0 {
+			return c
+		}
+		return cmp.Compare(aHi, bHi)
+	}
+
+	if c := cmp.Compare(a.year(), b.year()); c != 0 {
+		return c
 	}
 
 	if a.Month < b.Month {
@@ -85,3 +111,13 @@ func CmpDateToDateRange(a *Date, b *Range) int {
 	}
 	return 0
 }
+
+// cmpBools treats false as less than true.
+func cmpBools(a, b bool) int {
+	if a == b {
+		return 0
+	} else if a {
+		return 1
+	}
+	return -1
+}
@@ -55,6 +55,90 @@ func TestCmpDates(t *testing.T) {
 			InputB:   mustParseDate(t, "1 Feb 2024"),
 			Expected: 0,
 		},
+		{
+			Name:     "different calendars, same day",
+			InputA:   mustParseDate(t, "JULIAN 5 OCT 1582"),
+			InputB:   mustParseDate(t, "15 OCT 1582"),
+			Expected: 0,
+		},
+		{
+			Name:     "different calendars, a < b",
+			InputA:   mustParseDate(t, "JULIAN 12 MAR 1700"),
+			InputB:   mustParseDate(t, "24 MAR 1700"),
+			Expected: -1,
+		},
+		{
+			Name:     "different calendars, a is later despite lesser fields",
+			InputA:   mustParseDate(t, "JULIAN 10 MAR 1700"),
+			InputB:   mustParseDate(t, "15 MAR 1700"),
+			Expected: 1,
+		},
+		{
+			Name:     "different calendars, less precise date",
+			InputA:   mustParseDate(t, "JULIAN 1700"),
+			InputB:   mustParseDate(t, "1 JAN 1700"),
+			Expected: 1,
+		},
+		{
+			Name:     "different calendars, hebrew and gregorian, same day",
+			InputA:   mustParseDate(t, "HEBREW 1 TSH 5785"),
+			InputB:   mustParseDate(t, "3 OCT 2024"),
+			Expected: 0,
+		},
+		{
+			Name:     "different calendars, french republican and gregorian, a > b",
+			InputA:   mustParseDate(t, "FRENCH_R 18 BRUM VIII"),
+			InputB:   mustParseDate(t, "8 NOV 1799"),
+			Expected: 1,
+		},
+		{
+			Name:     "BCE year is less than CE year",
+			InputA:   mustParseDate(t, "44 BCE"),
+			InputB:   mustParseDate(t, "1 JAN 1"),
+			Expected: -1,
+		},
+		{
+			Name:     "BCE years count down",
+			InputA:   mustParseDate(t, "44 BCE"),
+			InputB:   mustParseDate(t, "753 BCE"),
+			Expected: 1,
+		},
+		{
+			Name:     "dual year is compared by the new style year",
+			InputA:   mustParseDate(t, "11 FEB 1731/32"),
+			InputB:   mustParseDate(t, "1 DEC 1731"),
+			Expected: 1,
+		},
+		{
+			Name:     "dual year, different calendars",
+			InputA:   mustParseDate(t, "JULIAN 11 FEB 1731/32"),
+			InputB:   mustParseDate(t, "22 FEB 1732"),
+			Expected: 0,
+		},
+		{
+			Name:     "phrase only is greater than a date with a year",
+			InputA:   date.NewPhrase("unknown"),
+			InputB:   mustParseDate(t, "1 JAN 2024"),
+			Expected: 1,
+		},
+		{
+			Name:     "a date with a year is less than phrase only",
+			InputA:   mustParseDate(t, "1 JAN 2024"),
+			InputB:   mustParseDate(t, "(Spring of 1850)"),
+			Expected: -1,
+		},
+		{
+			Name:     "both phrase only",
+			InputA:   date.NewPhrase("unknown"),
+			InputB:   mustParseDate(t, "(Spring of 1850)"),
+			Expected: 0,
+		},
+		{
+			Name:     "same calendar, hebrew months are in order within the year",
+			InputA:   mustParseDate(t, "HEBREW 1 ELL 5784"),
+			InputB:   mustParseDate(t, "HEBREW 1 TSH 5785"),
+			Expected: -1,
+		},
 	}
 
 	for _, test := range tests {
@@ -10,11 +10,18 @@
 // recognize upper-cased tokens. Another way that approximation tokens here are
 // looser than the actual GEDCOM v7 spec is that alternative words for certain
 // are accepted. Those are noted elsewhere in this package's documentation.
+//
+// Dates with Spanish, German, Dutch or French month names and approximation
+// words, such as "12 marzo 1890" or "vor 1800", are accepted too. So are
+// numeric dates, such as "1890-03-12" and "12/03/1890". See ParseOptions for
+// how to interpret the latter. Dates in EDTF, such as "1850~" or
+// "[1850..1860]", are also accepted, see ParseEDTF.
 package date
 
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -26,28 +33,73 @@ import (
 //   - nil, *Range, nil
 //   - nil, nil, error
 func Parse(in string) (*Date, *Range, error) {
+	return ParseWithOptions(in, ParseOptions{})
+}
+
+// ParseWithOptions is like Parse, but some ambiguous inputs are interpreted
+// with opts.
+func ParseWithOptions(in string, opts ParseOptions) (*Date, *Range, error) {
 	if len(in) > 100 {
 		return nil, nil, fmt.Errorf("max length is 100, but input is length %d", len(in))
 	}
 
-	rng, err := parseRange(in)
+	dat, err := parsePhrase(in, opts)
+	if errors.Is(err, errNotPhrase) {
+		// attempt another interpretation
+	} else if err != nil {
+		return nil, nil, err
+	} else {
+		return dat, nil, nil
+	}
+
+	dat, rng, err := parseEDTF(in)
+	if errors.Is(err, errNotEDTF) {
+		// attempt another interpretation
+	} else if err != nil {
+		return nil, nil, err
+	} else {
+		return dat, rng, nil
+	}
+
+	localized := localize(in, opts, false)
+	dat, rng, err = parseLocalized(in, localized)
+	if err == nil {
+		return dat, rng, nil
+	}
+	if short := localize(in, opts, true); short != localized {
+		if sdat, srng, serr := parseLocalized(in, short); serr == nil {
+			return sdat, srng, nil
+		}
+	}
+	return nil, nil, err
+}
+
+// parseLocalized interprets the localized version of the input as a Range, or
+// as a Date. The Payload of the output is the original input.
+func parseLocalized(in, localized string) (*Date, *Range, error) {
+	rng, err := parseRange(localized)
 	if errors.Is(err, errNotRange) {
 		// attempt another interpretation
 	} else if err != nil {
 		return nil, nil, err
 	} else {
+		rng.Payload = in
 		return nil, rng, nil
 	}
 
-	dat, err := parseDate(in)
-	return dat, nil, err
+	dat, err := parseDate(localized)
+	if err != nil {
+		return nil, nil, err
+	}
+	dat.Payload = in
+	return dat, nil, nil
 }
 
 // Date is a general-purpose date with the maximum resolution of 1 day. It's
 // meant to represent either a fully-known date, or an approximation of a date.
 // This status is indicated by the field, Approximate. From the GEDCOM7
-// specification, this type represents date, dateApprox, and DateExact. Only the
-// Gregorian calendar is supported.
+// specification, this type represents date, dateApprox, and DateExact. The
+// Gregorian, Julian, Hebrew and French Republican calendars are supported.
 //
 // Some ABNF snippets from the GEDCOM7 spec. But first, a brief primer on ABNF
 // notation.
@@ -65,24 +117,39 @@ func Parse(in string) (*Date, *Range, error) {
 //	dateApprox	= (%s"ABT" / %s"CAL" / %s"EST") D date
 //	DateExact	= day D month D year
 //
-// This package only considers the Gregorian calendar, and ignores the epoch
-// (such as BCE). Also, it is looser with regards to approximation words (such
-// as "ABT", "CAL", "EST"), in that it is case-insensitive and will allow for
-// expanded versions of said approximation words. One example is that any of
-// "Abt", "Abt.", "About", "ABT" are interpreted as the same thing. The
-// actual interpretation in this package is more like the ABNF notation was:
+// The epoch may be written like "BCE", "BC", "B.C." or "B.C.E.". The year may
+// also be a dual year from the GEDCOM 5.5.1 spec, such as "1731/32". So are
+// date phrases, such as "(Spring of 1850)", and interpreted dates, such as
+// "INT 1850 (about the time of the war)". Also, this package is looser with
+// regards to approximation words (such as "ABT", "CAL", "EST"), in that it is
+// case-insensitive and will allow for expanded versions of said approximation
+// words. One example is that any of "Abt", "Abt.", "About", "ABT" are
+// interpreted as the same thing. The actual interpretation in this package is
+// more like the ABNF notation was:
 //
-//	date 		= [[day D] month] year
+//	date 		= [calendar D] [[day D] month] year [D epoch]
 //	dateApprox	= (about / calculated / estimated) date
 //	DateExact	= day D month D year
 //	about		= (ABT / Abt. / about)
 //	calculated	= (CAL / Cal. / calculated)
 //	estimated	= (EST / Est. / estimated)
 type Date struct {
-	// Year is from the Gregorian calendar.
+	// Calendar is the calendar in which the Year, Month, Day fields are
+	// expressed. The zero value is the Gregorian calendar.
+	Calendar Calendar
+	// Year is from the Calendar. It's always positive, see the BCE field for
+	// years before the common era.
 	Year int
-	// Month is from the Gregorian calendar. It is 0 if the GEDCOM input did
-	// not specify a month in the first place.
+	// BCE indicates that the Year is before the common era. It's only
+	// applicable to the Gregorian and Julian calendars.
+	BCE bool
+	// DualYear is the year in the new style for a date that was recorded
+	// while the year started on March 25, such as 1732 in "11 FEB 1731/32". In
+	// that example, the Year field is 1731. It's 0 if the input did not have a
+	// dual year. It's only applicable to the Gregorian and Julian calendars.
+	DualYear int
+	// Month is from the Calendar. It is 0 if the GEDCOM input did not specify
+	// a month in the first place.
 	Month time.Month
 	// Day is the day of the month, if specified by the GEDCOM data. It would be
 	// 0 if the GEDCOM input did not specify a day of the month.
@@ -93,33 +160,54 @@ type Date struct {
 	// Another way this can be true is if the original input omitted the day of
 	// the month, or omitted both the day of the month and the month.
 	Approximate bool
+	// Qualifier is the GEDCOM word that explicitly made the date approximate,
+	// it's one of "ABT", "CAL", "EST", "INT". It's empty if the original input
+	// did not have such a word.
+	Qualifier string
 	// Payload is the original input data from the GEDCOM node.
 	Payload string
+	// Phrase is free text about the date, such as "Spring of 1850". If the
+	// Year field is 0, then the Date is phrase-only, because the original
+	// input could not be interpreted as a date. Otherwise, it's a textual
+	// alternative to the date fields.
+	Phrase string
 	// Display is an opinionated presentation of the date fields in a layout
 	// that strives to be like YYYY-MM-DD using the available non-zero data and
-	// indicating approximation with the prefix ~. It is not part of any GEDCOM
-	// spec. Some example values:
+	// indicating approximation with the prefix ~. A date from a calendar other
+	// than the Gregorian calendar keeps its original values and is suffixed
+	// with the name of its calendar. It is not part of any GEDCOM spec. Some
+	// example values:
 	//
 	//	2006-01-02
 	//	~ 2006-01-02
 	//	~ 2006-01
 	//	~ 2006
+	//	1700-03-12 (Julian)
+	//	1731/32-02-11
+	//	~ 44-03-15 BCE
+	//	~ 1850 (about the time of the war)
+	//	(Spring of 1850)
 	Display string
 }
 
 // setDisplay needs to be called after the Date has had all opportunities to
 // check whether or not it is an Approximate date or not.
 func (d *Date) setDisplay() {
+	if d.PhraseOnly() {
+		d.Display = "(" + d.Phrase + ")"
+		return
+	}
+
 	var b strings.Builder
 	if d.Approximate {
 		_, _ = b.WriteString("~ ")
 	}
 
 	parts := make([]string, 0, 3)
-	parts = append(parts, strconv.Itoa(d.Year))
+	parts = append(parts, d.formatYearWithoutEpoch())
 
 	var monthPart string
-	if d.Month < time.January || d.Month > time.December {
+	if d.Month < time.January || int(d.Month) > d.Calendar.monthsInYear() {
 		// no op
 	} else if d.Month < time.October {
 		monthPart = "0" + strconv.Itoa(int(d.Month))
@@ -137,6 +225,15 @@ func (d *Date) setDisplay() {
 		}
 	}
 	_, _ = b.WriteString(strings.Join(parts, "-"))
+	if d.BCE {
+		_, _ = b.WriteString(" BCE")
+	}
+	if d.Calendar != Gregorian {
+		_, _ = b.WriteString(" (" + d.Calendar.displayName() + ")")
+	}
+	if d.Phrase != "" {
+		_, _ = b.WriteString(" (" + d.Phrase + ")")
+	}
 
 	d.Display = b.String()
 }
@@ -144,6 +241,8 @@ func (d *Date) setDisplay() {
 const (
 	dateLayoutDMYAbbrevMonth = "2 Jan 2006"
 	dateLayoutDMYFullMonth   = "2 January 2006"
+	dateLayoutMDYAbbrevMonth = "Jan 2 2006"
+	dateLayoutMDYFullMonth   = "January 2 2006"
 	dateLayoutMYAbbrevMonth  = "Jan 2006"
 	dateLayoutMYFullMonth    = "January 2006"
 	dateLayoutY              = "2006"
@@ -152,24 +251,29 @@ const (
 var dateLayouts = []string{
 	dateLayoutDMYAbbrevMonth,
 	dateLayoutDMYFullMonth,
+	dateLayoutMDYAbbrevMonth,
+	dateLayoutMDYFullMonth,
 	dateLayoutMYAbbrevMonth,
 	dateLayoutMYFullMonth,
 	dateLayoutY,
 }
 
-func parseDate(in string) (out *Date, err error) {
-	errs := make([]error, 0, len(dateLayouts))
-	for _, layout := range dateLayouts {
-		out, err = parseDateWithApproximationToken(layout, in)
-		if err != nil {
-			errs = append(errs, err)
-		} else {
-			return
-		}
-	}
+var (
+	ordinalSuffix = regexp.MustCompile(`(?i)\b(\d+)(st|nd|rd|th)\b`)
+	extraSpaces   = regexp.MustCompile(`\s+`)
+)
+
+// normalizeSpelling tidies up some common ways that vendors, or people, write
+// dates that do not follow the GEDCOM spec. For example, "March 3rd, 1890"
+// becomes "March 3 1890".
+func normalizeSpelling(in string) string {
+	out := strings.ReplaceAll(in, ",", " ")
+	out = ordinalSuffix.ReplaceAllString(out, "$1")
+	return strings.TrimSpace(extraSpaces.ReplaceAllString(out, " "))
+}
 
-	err = errors.Join(errs...)
-	return
+func parseDate(in string) (out *Date, err error) {
+	return parseDateWithApproximationToken(in)
 }
 
 func parse(layout, in string) (*Date, error) {
@@ -113,12 +113,411 @@ func TestParseDate(t *testing.T) {
 	})
 }
 
+func TestParseDateCalendar(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expected    date.Date
+		ExpectError bool
+	}{
+		{
+			Name:     "explicit gregorian",
+			Input:    "GREGORIAN 2 JAN 2006",
+			Expected: date.Date{Year: 2006, Month: time.January, Day: 2, Display: "2006-01-02"},
+		},
+		{
+			Name:     "julian, all parts present",
+			Input:    "JULIAN 12 MAR 1700",
+			Expected: date.Date{Calendar: date.Julian, Year: 1700, Month: time.March, Day: 12, Display: "1700-03-12 (Julian)"},
+		},
+		{
+			Name:     "julian, mixed case, month fully spelled out",
+			Input:    "Julian 12 March 1700",
+			Expected: date.Date{Calendar: date.Julian, Year: 1700, Month: time.March, Day: 12, Display: "1700-03-12 (Julian)"},
+		},
+		{
+			Name:     "julian, only month year",
+			Input:    "JULIAN MAR 1700",
+			Expected: date.Date{Calendar: date.Julian, Year: 1700, Month: time.March, Approximate: true, Display: "~ 1700-03 (Julian)"},
+		},
+		{
+			Name:     "julian, only year",
+			Input:    "JULIAN 1700",
+			Expected: date.Date{Calendar: date.Julian, Year: 1700, Approximate: true, Display: "~ 1700 (Julian)"},
+		},
+		{
+			Name:     "julian, leap day in a year that is not a gregorian leap year",
+			Input:    "JULIAN 29 FEB 1700",
+			Expected: date.Date{Calendar: date.Julian, Year: 1700, Month: time.February, Day: 29, Display: "1700-02-29 (Julian)"},
+		},
+		{
+			Name:     "julian, GEDCOM 5.5.1 escape sequence",
+			Input:    "@#DJULIAN@ 12 MAR 1700",
+			Expected: date.Date{Calendar: date.Julian, Year: 1700, Month: time.March, Day: 12, Display: "1700-03-12 (Julian)"},
+		},
+		{
+			Name:     "julian, approximate",
+			Input:    "ABT JULIAN 12 MAR 1700",
+			Expected: date.Date{Calendar: date.Julian, Year: 1700, Month: time.March, Day: 12, Approximate: true, Display: "~ 1700-03-12 (Julian)"},
+		},
+		{
+			Name:        "julian, invalid day",
+			Input:       "JULIAN 30 FEB 1700",
+			ExpectError: true,
+		},
+		{
+			Name:        "julian, invalid month",
+			Input:       "JULIAN 12 FOO 1700",
+			ExpectError: true,
+		},
+		{
+			Name:     "hebrew, all parts present",
+			Input:    "HEBREW 1 TSH 5785",
+			Expected: date.Date{Calendar: date.Hebrew, Year: 5785, Month: 1, Day: 1, Display: "5785-01-01 (Hebrew)"},
+		},
+		{
+			Name:     "hebrew, GEDCOM 5.5.1 escape sequence, month fully spelled out",
+			Input:    "@#DHEBREW@ 15 Nisan 5610",
+			Expected: date.Date{Calendar: date.Hebrew, Year: 5610, Month: 8, Day: 15, Display: "5610-08-15 (Hebrew)"},
+		},
+		{
+			Name:     "hebrew, second adar in a leap year",
+			Input:    "HEBREW 14 ADS 5784",
+			Expected: date.Date{Calendar: date.Hebrew, Year: 5784, Month: 7, Day: 14, Display: "5784-07-14 (Hebrew)"},
+		},
+		{
+			Name:        "hebrew, second adar in a common year",
+			Input:       "HEBREW 14 ADS 5785",
+			ExpectError: true,
+		},
+		{
+			Name:        "hebrew, day 30 of a short month",
+			Input:       "HEBREW 30 TVT 5785",
+			ExpectError: true,
+		},
+		{
+			Name:     "french republican, roman numeral year",
+			Input:    "FRENCH_R 18 BRUM VIII",
+			Expected: date.Date{Calendar: date.FrenchRepublican, Year: 8, Month: 2, Day: 18, Display: "8-02-18 (French Republican)"},
+		},
+		{
+			Name:     "french republican, GEDCOM 5.5.1 escape sequence has a space",
+			Input:    "@#DFRENCH R@ 1 VEND 14",
+			Expected: date.Date{Calendar: date.FrenchRepublican, Year: 14, Month: 1, Day: 1, Display: "14-01-01 (French Republican)"},
+		},
+		{
+			Name:     "french republican, leap day in a sextile year",
+			Input:    "FRENCH_R 6 COMP III",
+			Expected: date.Date{Calendar: date.FrenchRepublican, Year: 3, Month: 13, Day: 6, Display: "3-13-06 (French Republican)"},
+		},
+		{
+			Name:     "french republican, only month year",
+			Input:    "FRENCH_R Thermidor II",
+			Expected: date.Date{Calendar: date.FrenchRepublican, Year: 2, Month: 11, Approximate: true, Display: "~ 2-11 (French Republican)"},
+		},
+		{
+			Name:        "french republican, leap day in a common year",
+			Input:       "FRENCH_R 6 COMP IV",
+			ExpectError: true,
+		},
+		{
+			Name:        "french republican, invalid roman numeral",
+			Input:       "FRENCH_R 1 VEND XIQ",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dat, _, err := date.Parse(test.Input)
+			if err != nil && !test.ExpectError {
+				t.Fatal(err)
+			} else if err == nil && test.ExpectError {
+				t.Fatal("expected error but got nil")
+			} else if err != nil && test.ExpectError {
+				return
+			}
+
+			test.Expected.Payload = test.Input
+			testDate(t, dat, &test.Expected)
+		})
+	}
+
+	t.Run("in a range", func(t *testing.T) {
+		rng := mustParseRange(t, "BET JULIAN 1700 AND @#DJULIAN@ 1701")
+		testDate(t, rng.Lo, &date.Date{Calendar: date.Julian, Year: 1700, Approximate: true, Display: "~ 1700 (Julian)"})
+		testDate(t, rng.Hi, &date.Date{Calendar: date.Julian, Year: 1701, Approximate: true, Display: "~ 1701 (Julian)"})
+	})
+}
+
+func TestParseDateEpochAndDualYear(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expected    date.Date
+		ExpectError bool
+	}{
+		{
+			Name:     "BCE, all parts present",
+			Input:    "15 MAR 44 BCE",
+			Expected: date.Date{Year: 44, BCE: true, Month: time.March, Day: 15, Display: "44-03-15 BCE"},
+		},
+		{
+			Name:     "BCE, only year",
+			Input:    "753 BCE",
+			Expected: date.Date{Year: 753, BCE: true, Approximate: true, Display: "~ 753 BCE"},
+		},
+		{
+			Name:     "BCE, alternative token, approximate, julian",
+			Input:    "ABT JULIAN 44 B.C.",
+			Expected: date.Date{Calendar: date.Julian, Year: 44, BCE: true, Approximate: true, Display: "~ 44 BCE (Julian)"},
+		},
+		{
+			Name:     "year with fewer than 4 digits",
+			Input:    "25 DEC 800",
+			Expected: date.Date{Year: 800, Month: time.December, Day: 25, Display: "800-12-25"},
+		},
+		{
+			Name:     "dual year",
+			Input:    "11 FEB 1731/32",
+			Expected: date.Date{Year: 1731, DualYear: 1732, Month: time.February, Day: 11, Display: "1731/32-02-11"},
+		},
+		{
+			Name:     "dual year, across a century",
+			Input:    "MAR 1699/00",
+			Expected: date.Date{Year: 1699, DualYear: 1700, Month: time.March, Approximate: true, Display: "~ 1699/00-03"},
+		},
+		{
+			Name:     "dual year, single digit",
+			Input:    "1749/0",
+			Expected: date.Date{Year: 1749, DualYear: 1750, Approximate: true, Display: "~ 1749/50"},
+		},
+		{
+			Name:        "dual year, not consecutive",
+			Input:       "11 FEB 1731/35",
+			ExpectError: true,
+		},
+		{
+			Name:        "dual year, not a number",
+			Input:       "11 FEB 1731/AB",
+			ExpectError: true,
+		},
+		{
+			Name:        "BCE in a calendar without epochs",
+			Input:       "HEBREW 100 BCE",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dat, _, err := date.Parse(test.Input)
+			if err != nil && !test.ExpectError {
+				t.Fatal(err)
+			} else if err == nil && test.ExpectError {
+				t.Fatal("expected error but got nil")
+			} else if err != nil && test.ExpectError {
+				return
+			}
+
+			test.Expected.Payload = test.Input
+			testDate(t, dat, &test.Expected)
+		})
+	}
+
+	t.Run("in a range", func(t *testing.T) {
+		rng := mustParseRange(t, "BET 100 BC AND 11 FEB 1731/32")
+		if rng.Payload != "BET 100 BC AND 11 FEB 1731/32" {
+			t.Errorf("wrong Payload; got %q", rng.Payload)
+		}
+		testDate(t, rng.Lo, &date.Date{Year: 100, BCE: true, Approximate: true, Display: "~ 100 BCE"})
+		testDate(t, rng.Hi, &date.Date{Year: 1731, DualYear: 1732, Month: time.February, Day: 11, Display: "1731/32-02-11"})
+	})
+}
+
+func TestParseDatePhrase(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expected    date.Date
+		ExpectError bool
+	}{
+		{
+			Name:     "phrase only",
+			Input:    "(Spring of 1850)",
+			Expected: date.Date{Phrase: "Spring of 1850", Display: "(Spring of 1850)"},
+		},
+		{
+			Name:     "interpreted date",
+			Input:    "INT 1850 (about the time of the war)",
+			Expected: date.Date{Year: 1850, Approximate: true, Phrase: "about the time of the war", Display: "~ 1850 (about the time of the war)"},
+		},
+		{
+			Name:     "interpreted date, mixed case, all parts present",
+			Input:    "Int 12 MAR 1850 (the day after the storm)",
+			Expected: date.Date{Year: 1850, Month: time.March, Day: 12, Approximate: true, Phrase: "the day after the storm", Display: "~ 1850-03-12 (the day after the storm)"},
+		},
+		{
+			Name:        "interpreted date without a phrase",
+			Input:       "INT 1850",
+			ExpectError: true,
+		},
+		{
+			Name:        "interpreted date, invalid date",
+			Input:       "INT 30 FEB 1850 (text)",
+			ExpectError: true,
+		},
+		{
+			Name:        "free text is not a phrase without parentheses",
+			Input:       "unknown",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dat, _, err := date.Parse(test.Input)
+			if err != nil && !test.ExpectError {
+				t.Fatal(err)
+			} else if err == nil && test.ExpectError {
+				t.Fatal("expected error but got nil")
+			} else if err != nil && test.ExpectError {
+				return
+			}
+
+			test.Expected.Payload = test.Input
+			testDate(t, dat, &test.Expected)
+			if dat.PhraseOnly() != (test.Expected.Year == 0) {
+				t.Errorf("wrong PhraseOnly; got %t", dat.PhraseOnly())
+			}
+		})
+	}
+
+	t.Run("NewPhrase", func(t *testing.T) {
+		got := date.NewPhrase("unknown")
+		testDate(t, got, &date.Date{Phrase: "unknown", Payload: "unknown", Display: "(unknown)"})
+		if !got.PhraseOnly() {
+			t.Error("expected PhraseOnly to be true")
+		}
+	})
+
+	t.Run("SetPhrase", func(t *testing.T) {
+		got := mustParseDate(t, "ABT 1850")
+		got.SetPhrase("during the war")
+		testDate(t, got, &date.Date{Year: 1850, Approximate: true, Phrase: "during the war", Payload: "ABT 1850", Display: "~ 1850 (during the war)"})
+		if got.PhraseOnly() {
+			t.Error("expected PhraseOnly to be false")
+		}
+	})
+}
+
+func TestDateIn(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Calendar    date.Calendar
+		Expected    date.Date
+		ExpectError bool
+	}{
+		{
+			Name:     "gregorian to julian",
+			Input:    "15 OCT 1582",
+			Calendar: date.Julian,
+			Expected: date.Date{Calendar: date.Julian, Year: 1582, Month: time.October, Day: 5, Display: "1582-10-05 (Julian)"},
+		},
+		{
+			Name:     "hebrew new year to gregorian",
+			Input:    "HEBREW 1 TSH 5785",
+			Calendar: date.Gregorian,
+			Expected: date.Date{Year: 2024, Month: time.October, Day: 3, Display: "2024-10-03"},
+		},
+		{
+			Name:     "hebrew leap year to gregorian",
+			Input:    "HEBREW 14 ADS 5784",
+			Calendar: date.Gregorian,
+			Expected: date.Date{Year: 2024, Month: time.March, Day: 24, Display: "2024-03-24"},
+		},
+		{
+			Name:     "gregorian to hebrew",
+			Input:    "9 APR 2024",
+			Calendar: date.Hebrew,
+			Expected: date.Date{Calendar: date.Hebrew, Year: 5784, Month: 8, Day: 1, Display: "5784-08-01 (Hebrew)"},
+		},
+		{
+			Name:     "french republican to gregorian",
+			Input:    "FRENCH_R 18 BRUM VIII",
+			Calendar: date.Gregorian,
+			Expected: date.Date{Year: 1799, Month: time.November, Day: 9, Display: "1799-11-09"},
+		},
+		{
+			Name:     "gregorian to french republican",
+			Input:    "1 JAN 1806",
+			Calendar: date.FrenchRepublican,
+			Expected: date.Date{Calendar: date.FrenchRepublican, Year: 14, Month: 4, Day: 11, Display: "14-04-11 (French Republican)"},
+		},
+		{
+			Name:     "approximation is kept",
+			Input:    "ABT FRENCH_R 1 VEND XIV",
+			Calendar: date.Gregorian,
+			Expected: date.Date{Year: 1805, Month: time.September, Day: 23, Approximate: true, Display: "~ 1805-09-23"},
+		},
+		{
+			Name:     "julian BCE to gregorian",
+			Input:    "JULIAN 15 MAR 44 BCE",
+			Calendar: date.Gregorian,
+			Expected: date.Date{Year: 44, BCE: true, Month: time.March, Day: 13, Display: "44-03-13 BCE"},
+		},
+		{
+			Name:     "dual year is converted by the new style year",
+			Input:    "JULIAN 11 FEB 1731/32",
+			Calendar: date.Gregorian,
+			Expected: date.Date{Year: 1732, Month: time.February, Day: 22, Display: "1732-02-22"},
+		},
+		{
+			Name:        "before the start of the calendar",
+			Input:       "1 JAN 1700",
+			Calendar:    date.FrenchRepublican,
+			ExpectError: true,
+		},
+		{
+			Name:        "imprecise date",
+			Input:       "HEBREW 5785",
+			Calendar:    date.Gregorian,
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := mustParseDate(t, test.Input).In(test.Calendar)
+			if err != nil && !test.ExpectError {
+				t.Fatal(err)
+			} else if err == nil && test.ExpectError {
+				t.Fatal("expected error but got nil")
+			} else if err != nil && test.ExpectError {
+				return
+			}
+
+			testDate(t, got, &test.Expected)
+		})
+	}
+}
+
 func testDate(t *testing.T, got, exp *date.Date) {
 	t.Helper()
 
+	if got.Calendar != exp.Calendar {
+		t.Errorf("wrong Calendar; got %q, expected %q", got.Calendar, exp.Calendar)
+	}
 	if got.Year != exp.Year {
 		t.Errorf("wrong Year; got %d, expected %d", got.Year, exp.Year)
 	}
+	if got.BCE != exp.BCE {
+		t.Errorf("wrong BCE; got %t, expected %t", got.BCE, exp.BCE)
+	}
+	if got.DualYear != exp.DualYear {
+		t.Errorf("wrong DualYear; got %d, expected %d", got.DualYear, exp.DualYear)
+	}
 	if got.Month != exp.Month {
 		t.Errorf("wrong Month; got %d, expected %d", got.Month, exp.Month)
 	}
@@ -128,6 +527,9 @@ func testDate(t *testing.T, got, exp *date.Date) {
 	if got.Approximate != exp.Approximate {
 		t.Errorf("wrong Approximate; got %t, expected %t", got.Approximate, exp.Approximate)
 	}
+	if got.Phrase != exp.Phrase {
+		t.Errorf("wrong Phrase; got %q, expected %q", got.Phrase, exp.Phrase)
+	}
 	if got.Payload != exp.Payload {
 		t.Errorf("wrong Payload; got %q, expected %q", got.Payload, exp.Payload)
 	}
@@ -161,3 +563,16 @@ func mustParseRange(t *testing.T, in string) (out *date.Range) {
 
 	return
 }
+
+func mustParseValue(t *testing.T, in string) date.Value {
+	t.Helper()
+
+	dat, rng, err := date.Parse(in)
+	if err != nil {
+		t.Fatalf("bad input %q: %v", in, err)
+	}
+	if dat != nil {
+		return dat
+	}
+	return rng
+}
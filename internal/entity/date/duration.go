@@ -0,0 +1,148 @@
+package date
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Value is either a *Date or a *Range. It's meant for functionality that works
+// the same way for either type.
+type Value interface {
+	// dayInterval is the earliest and latest possible day numbers. Either one
+	// may be unknown, such as the Hi bound for "AFT 1850".
+	dayInterval() (lo, hi int, hasLo, hasHi bool)
+}
+
+func (d *Date) dayInterval() (lo, hi int, hasLo, hasHi bool) {
+	if d.PhraseOnly() {
+		return
+	}
+	lo, hi = d.dayBounds()
+	return lo, hi, true, true
+}
+
+func (r *Range) dayInterval() (lo, hi int, hasLo, hasHi bool) {
+	if r.Lo != nil && !r.Lo.PhraseOnly() {
+		lo, _ = r.Lo.dayBounds()
+		hasLo = true
+	}
+	if r.Hi != nil && !r.Hi.PhraseOnly() {
+		_, hi = r.Hi.dayBounds()
+		hasHi = true
+	}
+	return
+}
+
+// Duration is the amount of time between two values, whose exact dates may be
+// unknown. So the Duration is expressed as a minimum and maximum. When both
+// values are exact dates, the minimum and maximum are the same.
+type Duration struct {
+	// MinDays and MaxDays are the shortest and longest possible number of days.
+	MinDays, MaxDays int
+	// MinYears and MaxYears are the fewest and most possible number of whole
+	// years, like how one would count a person's age.
+	MinYears, MaxYears int
+	// Unbounded means that there is no known maximum, so the fields MaxDays
+	// and MaxYears are meaningless. For example, the Duration from 1850 until
+	// "AFT 1900".
+	Unbounded bool
+}
+
+var (
+	errDurationUnknown  = errors.New("not enough information to calculate a duration")
+	errDurationNegative = errors.New("end of duration is before its start")
+)
+
+// NewDuration calculates the Duration between from and to, where from is the
+// earlier value. A date without a day or a month spans the whole month or year,
+// which widens the Duration. An approximate date is otherwise treated like an
+// exact date. It's an error if to is definitely before from, or if either value
+// has no dates to work with, such as a phrase-only Date.
+func NewDuration(from, to Value) (out Duration, err error) {
+	fromLo, fromHi, fromHasLo, fromHasHi := from.dayInterval()
+	toLo, toHi, toHasLo, toHasHi := to.dayInterval()
+	if !(fromHasLo || fromHasHi) || !(toHasLo || toHasHi) {
+		err = errDurationUnknown
+		return
+	}
+
+	if fromHasLo && toHasHi {
+		if out.MaxDays = toHi - fromLo; out.MaxDays < 0 {
+			err = errDurationNegative
+			return
+		}
+		out.MaxYears = wholeYearsBetween(fromLo, toHi)
+	} else {
+		out.Unbounded = true
+	}
+
+	if fromHasHi && toHasLo && toLo > fromHi {
+		out.MinDays = toLo - fromHi
+		out.MinYears = wholeYearsBetween(fromHi, toLo)
+	}
+
+	return
+}
+
+// wholeYearsBetween counts the number of anniversaries from day number a until
+// day number b.
+func wholeYearsBetween(a, b int) int {
+	ay, am, ad := Gregorian.fromDayNumber(a)
+	by, bm, bd := Gregorian.fromDayNumber(b)
+
+	out := by - ay
+	if bm < am || (bm == am && bd < ad) {
+		out--
+	}
+	return out
+}
+
+// String presents the Duration in whole years, or in days if it's less than a
+// year. Some example values:
+//
+//	72 years
+//	between 71 and 73 years
+//	at least 20 years
+//	between 100 and 130 days
+func (d Duration) String() string {
+	if d.Unbounded {
+		if d.MinYears > 0 {
+			return "at least " + pluralize(d.MinYears, "year")
+		}
+		return "at least " + pluralize(d.MinDays, "day")
+	}
+
+	if d.MaxYears < 1 {
+		if d.MinDays == d.MaxDays {
+			return pluralize(d.MinDays, "day")
+		}
+		return "between " + strconv.Itoa(d.MinDays) + " and " + pluralize(d.MaxDays, "day")
+	}
+
+	if d.MinYears == d.MaxYears {
+		return pluralize(d.MinYears, "year")
+	}
+	return "between " + strconv.Itoa(d.MinYears) + " and " + pluralize(d.MaxYears, "year")
+}
+
+func pluralize(n int, unit string) string {
+	out := strconv.Itoa(n) + " " + unit
+	if n != 1 {
+		out += "s"
+	}
+	return out
+}
+
+// Years is the earliest and latest possible Gregorian years of v. Years before
+// the common era are astronomical, ie: 1 BCE is 0. Either one may be unknown,
+// such as the latest year of "AFT 1850".
+func Years(v Value) (lo, hi int, hasLo, hasHi bool) {
+	loDay, hiDay, hasLo, hasHi := v.dayInterval()
+	if hasLo {
+		lo, _, _ = Gregorian.fromDayNumber(loDay)
+	}
+	if hasHi {
+		hi, _, _ = Gregorian.fromDayNumber(hiDay)
+	}
+	return
+}
@@ -0,0 +1,106 @@
+package date_test
+
+import (
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+)
+
+func TestNewDuration(t *testing.T) {
+	tests := []struct {
+		Name        string
+		From, To    string
+		Expected    date.Duration
+		ExpString   string
+		ExpectError bool
+	}{
+		{
+			Name:      "exact dates",
+			From:      "3 MAR 1850",
+			To:        "2 MAR 1922",
+			Expected:  date.Duration{MinDays: 26296, MaxDays: 26296, MinYears: 71, MaxYears: 71},
+			ExpString: "71 years",
+		},
+		{
+			Name:      "exact dates, on the anniversary",
+			From:      "3 MAR 1850",
+			To:        "3 MAR 1922",
+			Expected:  date.Duration{MinDays: 26297, MaxDays: 26297, MinYears: 72, MaxYears: 72},
+			ExpString: "72 years",
+		},
+		{
+			Name:      "only years",
+			From:      "ABT 1850",
+			To:        "1922",
+			Expected:  date.Duration{MinDays: 25933, MaxDays: 26661, MinYears: 71, MaxYears: 72},
+			ExpString: "between 71 and 72 years",
+		},
+		{
+			Name:      "range",
+			From:      "BET 1849 AND 1850",
+			To:        "1922",
+			Expected:  date.Duration{MinDays: 25933, MaxDays: 27026, MinYears: 71, MaxYears: 73},
+			ExpString: "between 71 and 73 years",
+		},
+		{
+			Name:      "open ended",
+			From:      "1 JAN 1850",
+			To:        "AFT 1 JAN 1900",
+			Expected:  date.Duration{MinDays: 18262, MinYears: 50, Unbounded: true},
+			ExpString: "at least 50 years",
+		},
+		{
+			Name:      "open ended, and could overlap",
+			From:      "1850",
+			To:        "BEF 1900",
+			Expected:  date.Duration{MaxDays: 18626, MaxYears: 50},
+			ExpString: "between 0 and 50 years",
+		},
+		{
+			Name:      "less than a year",
+			From:      "1 JAN 1850",
+			To:        "31 JAN 1850",
+			Expected:  date.Duration{MinDays: 30, MaxDays: 30},
+			ExpString: "30 days",
+		},
+		{
+			Name:      "different calendars",
+			From:      "JULIAN 5 OCT 1582",
+			To:        "15 OCT 1583",
+			Expected:  date.Duration{MinDays: 365, MaxDays: 365, MinYears: 1, MaxYears: 1},
+			ExpString: "1 year",
+		},
+		{
+			Name:        "to is before from",
+			From:        "1900",
+			To:          "1850",
+			ExpectError: true,
+		},
+		{
+			Name:        "phrase only",
+			From:        "1850",
+			To:          "(Spring of 1900)",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := date.NewDuration(mustParseValue(t, test.From), mustParseValue(t, test.To))
+			if err != nil && !test.ExpectError {
+				t.Fatal(err)
+			} else if err == nil && test.ExpectError {
+				t.Fatal("expected error but got nil")
+			} else if err != nil && test.ExpectError {
+				return
+			}
+
+			if got != test.Expected {
+				t.Errorf("wrong output; got %#v, expected %#v", got, test.Expected)
+			}
+			if got.String() != test.ExpString {
+				t.Errorf("wrong String; got %q, expected %q", got.String(), test.ExpString)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package date
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EDTF is the Extended Date/Time Format from the Library of Congress, read more
+// at https://www.loc.gov/standards/datetime. It's used by some archival
+// systems. This package supports the subset of EDTF that has an equivalent in
+// GEDCOM:
+//
+//	1850-03-12	a date, like "12 MAR 1850".
+//	1850~		an approximate date, like "ABT 1850".
+//	1850-03?	an uncertain date, like "EST MAR 1850".
+//	1850%		an approximate and uncertain date, like "CAL 1850".
+//	-0043		a year before the common era, like "44 BCE".
+//	1850/1860	an interval, like "FROM 1850 TO 1860".
+//	1850/..		an interval with an open end, like "FROM 1850".
+//	[1850..1860]	one of a set of dates, like "BET 1850 AND 1860".
+//	[..1800]	one of an open ended set of dates, like "BEF 1800".
+//
+// EDTF has no qualifier for a calculated date, so the GEDCOM qualifier CAL is
+// paired with "%", the one that's left. Each qualifier has exactly one
+// counterpart, so it survives a round trip in either direction.
+
+const (
+	edtfApproximate = "~"
+	edtfUncertain   = "?"
+	edtfBoth        = "%"
+	edtfOpen        = ".."
+)
+
+var (
+	edtfDatePattern  = `(-?\d{4})(?:-(\d{2})(?:-(\d{2}))?)?([~?%])?`
+	edtfDate         = regexp.MustCompile(`^` + edtfDatePattern + `$`)
+	edtfShapePattern = `-?\d{4}(?:-\d{2}(?:-\d{2})?)?[~?%]?`
+	edtfShape        = regexp.MustCompile(
+		`^(?:` + edtfShapePattern + // single date
+			`|(?:` + edtfShapePattern + `|\.\.)?/(?:` + edtfShapePattern + `|\.\.)?` + // interval
+			`|\[(?:` + edtfShapePattern + `)?\.\.(?:` + edtfShapePattern + `)?\])$`, // set
+	)
+)
+
+var errNotEDTF = errors.New("input does not appear to be EDTF at all")
+
+// ParseEDTF interprets in as an EDTF string. Like Parse, it will return one of
+// the following:
+//   - *Date, nil, nil
+//   - nil, *Range, nil
+//   - nil, nil, error
+func ParseEDTF(in string) (*Date, *Range, error) {
+	dat, rng, err := parseEDTF(in)
+	if errors.Is(err, errNotEDTF) {
+		return nil, nil, fmt.Errorf("%w: %q", err, in)
+	}
+	return dat, rng, err
+}
+
+// parseEDTF is like ParseEDTF, but the error is errNotEDTF if the input does
+// not look like EDTF in the first place.
+func parseEDTF(in string) (dat *Date, rng *Range, err error) {
+	trimmed := strings.TrimSpace(in)
+	if !edtfShape.MatchString(trimmed) {
+		err = errNotEDTF
+		return
+	}
+
+	if strings.HasPrefix(trimmed, "[") {
+		lo, hi, _ := strings.Cut(trimmed[1:len(trimmed)-1], edtfOpen)
+		rng, err = parseEDTFRange(lo, hi)
+	} else if lo, hi, found := strings.Cut(trimmed, "/"); found {
+		rng, err = parseEDTFRange(lo, hi)
+		if rng != nil {
+			rng.Period = true
+		}
+	} else {
+		dat, err = parseEDTFDate(trimmed)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if dat != nil {
+		dat.Payload = in
+	} else {
+		rng.Payload = in
+	}
+	return
+}
+
+func parseEDTFRange(lo, hi string) (out *Range, err error) {
+	out = &Range{}
+	if lo != "" && lo != edtfOpen {
+		if out.Lo, err = parseEDTFDate(lo); err != nil {
+			return nil, err
+		}
+	}
+	if hi != "" && hi != edtfOpen {
+		if out.Hi, err = parseEDTFDate(hi); err != nil {
+			return nil, err
+		}
+	}
+	if out.Lo == nil && out.Hi == nil {
+		return nil, errors.New("EDTF range should have at least one bound")
+	}
+	return
+}
+
+// parseEDTFDate rewrites a single EDTF date in GEDCOM format so that it's
+// validated like any other GEDCOM date.
+func parseEDTFDate(in string) (*Date, error) {
+	m := edtfDate.FindStringSubmatch(in)
+	if m == nil {
+		return nil, fmt.Errorf("%w: %q", errNotEDTF, in)
+	}
+
+	parts := make([]string, 0, 5)
+	switch m[4] {
+	case edtfApproximate:
+		parts = append(parts, "ABT")
+	case edtfUncertain:
+		parts = append(parts, "EST")
+	case edtfBoth:
+		parts = append(parts, "CAL")
+	}
+
+	if m[2] != "" {
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return nil, fmt.Errorf("invalid EDTF month in %q", in)
+		}
+		if m[3] != "" {
+			day, _ := strconv.Atoi(m[3])
+			parts = append(parts, strconv.Itoa(day))
+		}
+		parts = append(parts, Gregorian.monthToken(time.Month(month)))
+	}
+
+	// EDTF years are astronomical, so year 0 is 1 BCE.
+	year, _ := strconv.Atoi(m[1])
+	if year < 1 {
+		parts = append(parts, strconv.Itoa(1-year), "BCE")
+	} else {
+		parts = append(parts, strconv.Itoa(year))
+	}
+
+	out, err := parseDateWithApproximationToken(strings.Join(parts, " "))
+	if err != nil {
+		return nil, err
+	}
+	out.Payload = in
+	return out, nil
+}
+
+// EDTF presents the Date as an EDTF string, such as "1850-03-12" or "1850~".
+// A Date from another calendar is converted to the Gregorian calendar, but
+// only if it has a month and a day. Otherwise, the output is empty. The output
+// is also empty for a phrase-only Date.
+func (d *Date) EDTF() string {
+	out := d.edtfWithoutQualifier()
+	if out == "" {
+		return ""
+	}
+
+	switch d.Qualifier {
+	case "ABT":
+		out += edtfApproximate
+	case "EST":
+		out += edtfUncertain
+	case "CAL":
+		out += edtfBoth
+	}
+	return out
+}
+
+func (d *Date) edtfWithoutQualifier() string {
+	if d == nil || d.Year == 0 {
+		return ""
+	}
+
+	g := d
+	if d.Calendar != Gregorian {
+		var err error
+		if g, err = d.In(Gregorian); err != nil {
+			return ""
+		}
+	}
+
+	var b strings.Builder
+	year := g.year()
+	if year < 0 {
+		b.WriteString("-")
+		year = -year
+	}
+	fmt.Fprintf(&b, "%04d", year)
+	if g.Month != 0 {
+		fmt.Fprintf(&b, "-%02d", int(g.Month))
+		if g.Day != 0 {
+			fmt.Fprintf(&b, "-%02d", g.Day)
+		}
+	}
+	return b.String()
+}
+
+// EDTF presents the Range as an EDTF string. A Range with the Period field is
+// an interval, such as "1850/1860". Otherwise, it's one of a set of dates,
+// such as "[1850..1860]". The output is empty if either bound cannot be
+// presented as EDTF, see the EDTF method on Date.
+func (r *Range) EDTF() string {
+	var lo, hi string
+	if r.Lo != nil {
+		if lo = r.Lo.EDTF(); lo == "" {
+			return ""
+		}
+	}
+	if r.Hi != nil {
+		if hi = r.Hi.EDTF(); hi == "" {
+			return ""
+		}
+	}
+	if lo == "" && hi == "" {
+		return ""
+	}
+
+	if r.Period {
+		if lo == "" {
+			lo = edtfOpen
+		}
+		if hi == "" {
+			hi = edtfOpen
+		}
+		return lo + "/" + hi
+	}
+	return "[" + lo + edtfOpen + hi + "]"
+}
@@ -0,0 +1,131 @@
+package date_test
+
+import (
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+)
+
+func TestParseEDTF(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expected    string
+		ExpectError bool
+	}{
+		{Name: "date", Input: "1850-03-12", Expected: "12 MAR 1850"},
+		{Name: "year and month", Input: "1850-03", Expected: "MAR 1850"},
+		{Name: "approximate", Input: "1850~", Expected: "ABT 1850"},
+		{Name: "uncertain", Input: "1850-03?", Expected: "EST MAR 1850"},
+		{Name: "approximate and uncertain", Input: "1850-03-12%", Expected: "CAL 12 MAR 1850"},
+		{Name: "before the common era", Input: "-0043", Expected: "44 BCE"},
+		{Name: "year zero", Input: "0000", Expected: "1 BCE"},
+		{Name: "interval", Input: "1850/1860", Expected: "FROM 1850 TO 1860"},
+		{Name: "interval, open end", Input: "1850/..", Expected: "FROM 1850"},
+		{Name: "interval, unknown start", Input: "/1860", Expected: "TO 1860"},
+		{Name: "set", Input: "[1850..1860]", Expected: "BET 1850 AND 1860"},
+		{Name: "set, open start", Input: "[..1800]", Expected: "BEF 1800"},
+		{Name: "set, open end", Input: "[1800-03-12..]", Expected: "AFT 12 MAR 1800"},
+		{Name: "invalid month", Input: "1850-13", ExpectError: true},
+		{Name: "invalid day", Input: "1850-02-30", ExpectError: true},
+		{Name: "no bounds", Input: "../..", ExpectError: true},
+		{Name: "not edtf", Input: "12 MAR 1850", ExpectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dat, rng, err := date.ParseEDTF(test.Input)
+			if err != nil && !test.ExpectError {
+				t.Fatal(err)
+			} else if err == nil && test.ExpectError {
+				t.Fatal("expected error but got nil")
+			} else if err != nil && test.ExpectError {
+				return
+			}
+
+			var got, edtf string
+			if dat != nil {
+				got, edtf = dat.Format(), dat.EDTF()
+			} else {
+				got, edtf = rng.Format(), rng.EDTF()
+			}
+			if got != test.Expected {
+				t.Errorf("wrong output; got %q, expected %q", got, test.Expected)
+			}
+
+			// Parsing with Parse should have the same outcome.
+			dat, rng, err = date.Parse(test.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dat != nil && dat.Format() != got {
+				t.Errorf("wrong output from Parse; got %q, expected %q", dat.Format(), got)
+			} else if rng != nil && rng.Format() != got {
+				t.Errorf("wrong output from Parse; got %q, expected %q", rng.Format(), got)
+			}
+
+			// Round trip, except for an interval without any bounds.
+			if test.Input[0] != '/' && edtf != test.Input {
+				t.Errorf("wrong EDTF; got %q, expected %q", edtf, test.Input)
+			}
+		})
+	}
+}
+
+func TestEDTF(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{Name: "date", Input: "12 MAR 1850", Expected: "1850-03-12"},
+		{Name: "year", Input: "1850", Expected: "1850"},
+		{Name: "medieval year", Input: "800", Expected: "0800"},
+		{Name: "about", Input: "ABT MAR 1850", Expected: "1850-03~"},
+		{Name: "calculated", Input: "CAL 1850", Expected: "1850%"},
+		{Name: "estimated", Input: "EST 1850", Expected: "1850?"},
+		{Name: "interpreted", Input: "INT 1850 (after the war)", Expected: "1850"},
+		{Name: "before the common era", Input: "15 MAR 44 BCE", Expected: "-0043-03-15"},
+		{Name: "dual year", Input: "11 FEB 1731/32", Expected: "1732-02-11"},
+		{Name: "julian", Input: "JULIAN 5 OCT 1582", Expected: "1582-10-15"},
+		{Name: "julian without a day", Input: "JULIAN OCT 1582", Expected: ""},
+		{Name: "phrase only", Input: "(sometime)", Expected: ""},
+		{Name: "period", Input: "FROM 1850 TO 3 MAR 1860", Expected: "1850/1860-03-03"},
+		{Name: "period without end", Input: "FROM 1850", Expected: "1850/.."},
+		{Name: "period without start", Input: "TO 1850", Expected: "../1850"},
+		{Name: "between", Input: "BET 1850 AND 1860", Expected: "[1850..1860]"},
+		{Name: "after", Input: "AFT 1850", Expected: "[1850..]"},
+		{Name: "before", Input: "BEF 1850", Expected: "[..1850]"},
+		{Name: "range with a bound in another calendar", Input: "BET JULIAN 1582 AND 1590", Expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var got string
+			switch v := mustParseValue(t, test.Input).(type) {
+			case *date.Date:
+				got = v.EDTF()
+			case *date.Range:
+				got = v.EDTF()
+			}
+			if got != test.Expected {
+				t.Errorf("wrong output; got %q, expected %q", got, test.Expected)
+			}
+		})
+	}
+}
+
+func TestEDTFQualifierRoundTrip(t *testing.T) {
+	for _, input := range []string{"ABT 1850", "CAL MAR 1850", "EST 12 MAR 1850"} {
+		t.Run(input, func(t *testing.T) {
+			edtf := mustParseValue(t, input).(*date.Date).EDTF()
+			dat, _, err := date.ParseEDTF(edtf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := dat.Format(); got != input {
+				t.Errorf("wrong output after round trip through %q; got %q, expected %q", edtf, got, input)
+			}
+		})
+	}
+}
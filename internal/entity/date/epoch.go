@@ -0,0 +1,125 @@
+package date
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// epochTokens mark a year as before the common era. In the GEDCOM7 spec, the
+// ABNF grammar for an epoch is:
+//
+//	epoch = %s"BCE" / extTag
+//
+// Variations such as "BC", "B.C." and "B.C.E." are accepted, in any casing, to
+// work with data from vendors that do not follow the spec. The epoch is only
+// applicable to the Gregorian and Julian calendars.
+var epochTokens = []string{"BCE", "B.C.E.", "BC", "B.C."}
+
+// splitEpoch detects a trailing epoch token in the input and returns the rest
+// of the input.
+func splitEpoch(in string) (bce bool, rest string) {
+	before, last, found := cutLast(in)
+	if !found {
+		return false, in
+	}
+
+	ulast := strings.ToUpper(last)
+	for _, token := range epochTokens {
+		if ulast == token {
+			return true, before
+		}
+	}
+	return false, in
+}
+
+// splitDualYear detects a GEDCOM 5.5.1 dual year, such as "1731/32", at the end
+// of the input. It's used for dates between January 1 and March 24 while the
+// year, in some places, started on March 25. The rest of the input has the
+// first year, which is the year in the old style. The dual year is the year in
+// the new style. It's 0 if there is no dual year.
+func splitDualYear(in string) (dualYear int, rest string, err error) {
+	before, last, found := cutLast(in)
+	if !found {
+		before = ""
+	}
+
+	yearPart, dualPart, found := strings.Cut(last, "/")
+	if !found {
+		return 0, in, nil
+	}
+
+	year, yerr := strconv.Atoi(yearPart)
+	suffix, serr := strconv.Atoi(dualPart)
+	if yerr != nil || serr != nil || len(dualPart) > len(yearPart) {
+		err = fmt.Errorf("invalid dual year %q", last)
+		return
+	}
+
+	// The dual part usually only has the last 2 digits, so fill in the rest
+	// from the first year.
+	mod := 1
+	for range dualPart {
+		mod *= 10
+	}
+	dualYear = year - year%mod + suffix
+	if dualYear <= year {
+		dualYear += mod
+	}
+	if dualYear != year+1 {
+		err = fmt.Errorf("invalid dual year %q, years should be consecutive", last)
+		return
+	}
+
+	rest = strings.TrimSpace(before + " " + yearPart)
+	return
+}
+
+// cutLast slices in around the last space.
+func cutLast(in string) (before, last string, found bool) {
+	ind := strings.LastIndex(in, " ")
+	if ind < 0 {
+		return "", in, false
+	}
+	return strings.TrimSpace(in[:ind]), in[ind+1:], true
+}
+
+// year is the Year as a number that's comparable to the Year of any other Date
+// in the same calendar. A year before the common era is counted like the
+// astronomical year numbering, where 1 BCE is 0, 2 BCE is -1, and so on. A
+// dual year is counted by its new style year.
+func (d *Date) year() int {
+	if d.BCE {
+		return 1 - d.Year
+	}
+	if d.DualYear != 0 {
+		return d.DualYear
+	}
+	return d.Year
+}
+
+// FormatYear presents the year, including the dual year and epoch if present.
+// Some example values:
+//
+//	1731
+//	1731/32
+//	44 BCE
+func (d *Date) FormatYear() string {
+	out := d.formatYearWithoutEpoch()
+	if d.BCE {
+		out += " BCE"
+	}
+	return out
+}
+
+func (d *Date) formatYearWithoutEpoch() string {
+	out := strconv.Itoa(d.Year)
+	if d.DualYear != 0 {
+		dual := strconv.Itoa(d.DualYear)
+		if len(dual) > 2 {
+			dual = dual[len(dual)-2:]
+		}
+		out += "/" + dual
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package date
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Format presents the Date as a canonical GEDCOM7 date value, such as
+// "ABT 3 MAR 1890" or "JULIAN 12 MAR 1700". Some things about the Date are not
+// expressible in the GEDCOM7 date value itself:
+//   - The Phrase field. In GEDCOM7, it belongs in a PHRASE substructure.
+//   - A dual year. The output uses the new style year, ie: the DualYear field.
+//   - The INT qualifier, which was removed in GEDCOM7. The output uses no
+//     qualifier, and the Phrase should go into a PHRASE substructure.
+//
+// A phrase-only Date has an empty output, because GEDCOM7 expects the phrase
+// to be in a PHRASE substructure of an empty DATE.
+func (d *Date) Format() string {
+	if d.PhraseOnly() {
+		return ""
+	}
+
+	parts := make([]string, 0, 6)
+	if d.Qualifier != "" && d.Qualifier != interpretedToken {
+		parts = append(parts, d.Qualifier)
+	}
+	parts = append(parts, d.formatWithoutQualifier())
+	return strings.Join(parts, " ")
+}
+
+// formatWithoutQualifier is the GEDCOM7 date symbol, which does not have any
+// approximation words. It's what goes into a Range.
+func (d *Date) formatWithoutQualifier() string {
+	parts := make([]string, 0, 5)
+	if d.Calendar != Gregorian {
+		parts = append(parts, d.Calendar.String())
+	}
+	if d.Month != 0 {
+		if d.Day != 0 {
+			parts = append(parts, strconv.Itoa(d.Day))
+		}
+		parts = append(parts, d.Calendar.monthToken(d.Month))
+	}
+
+	year := d.Year
+	if d.DualYear != 0 {
+		year = d.DualYear
+	}
+	parts = append(parts, strconv.Itoa(year))
+	if d.BCE {
+		parts = append(parts, "BCE")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Format presents the Range as a canonical GEDCOM7 date value, such as
+// "BET 1850 AND 1860" or "FROM 1 JAN 1900 TO 1910". Like with a Date, the
+// Phrase field is not part of the output.
+func (r *Range) Format() string {
+	var lo, hi string
+	if r.Lo != nil {
+		lo = r.Lo.formatWithoutQualifier()
+	}
+	if r.Hi != nil {
+		hi = r.Hi.formatWithoutQualifier()
+	}
+
+	if r.Period {
+		if lo != "" && hi != "" {
+			return rangeTokenFrom + " " + lo + " " + rangeTokenTo + " " + hi
+		} else if lo != "" {
+			return rangeTokenFrom + " " + lo
+		} else if hi != "" {
+			return rangeTokenTo + " " + hi
+		}
+		return ""
+	}
+
+	if lo != "" && hi != "" {
+		return rangeTokenBet + " " + lo + " " + rangeTokenAnd + " " + hi
+	} else if lo != "" {
+		return rangeTokenAft + " " + lo
+	} else if hi != "" {
+		return rangeTokenBef + " " + hi
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package date_test
+
+import (
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		Input    string
+		Expected string
+	}{
+		// dates
+		{Input: "3 MAR 1890", Expected: "3 MAR 1890"},
+		{Input: "2 January 2006", Expected: "2 JAN 2006"},
+		{Input: "Jan 2006", Expected: "JAN 2006"},
+		{Input: "2006", Expected: "2006"},
+		{Input: "abt 2006", Expected: "ABT 2006"},
+		{Input: "About 2006", Expected: "ABT 2006"},
+		{Input: "Cal. 2006", Expected: "CAL 2006"},
+		{Input: "est 2006", Expected: "EST 2006"},
+		{Input: "Abt. March 3rd 1890", Expected: "ABT 3 MAR 1890"},
+		{Input: "March 3, 1890", Expected: "3 MAR 1890"},
+		{Input: "21st Jan 1900", Expected: "21 JAN 1900"},
+		{Input: "@#DJULIAN@ 12 March 1700", Expected: "JULIAN 12 MAR 1700"},
+		{Input: "@#DHEBREW@ 1 TSH 5785", Expected: "HEBREW 1 TSH 5785"},
+		{Input: "@#DFRENCH R@ 18 BRUM VIII", Expected: "FRENCH_R 18 BRUM 8"},
+		{Input: "15 MAR 44 B.C.", Expected: "15 MAR 44 BCE"},
+		{Input: "11 FEB 1731/32", Expected: "11 FEB 1732"},
+		{Input: "INT 1850 (about the time of the war)", Expected: "1850"},
+		{Input: "(Spring of 1850)", Expected: ""},
+		// ranges
+		{Input: "bet 1850 and 1860", Expected: "BET 1850 AND 1860"},
+		{Input: "Between 1850 and 1860", Expected: "BET 1850 AND 1860"},
+		{Input: "aft 1850", Expected: "AFT 1850"},
+		{Input: "Before 2 Jan 1850", Expected: "BEF 2 JAN 1850"},
+		{Input: "from 1850 to 1860", Expected: "FROM 1850 TO 1860"},
+		{Input: "From 1850", Expected: "FROM 1850"},
+		{Input: "to 1860", Expected: "TO 1860"},
+		{Input: "BET @#DJULIAN@ 1700 AND 1701", Expected: "BET JULIAN 1700 AND 1701"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			dat, rng, err := date.Parse(test.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got string
+			if dat != nil {
+				got = dat.Format()
+			} else {
+				got = rng.Format()
+			}
+
+			if got != test.Expected {
+				t.Errorf("wrong output; got %q, expected %q", got, test.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package date
+
+import "time"
+
+// frenchMonths are the months of the French Republican calendar. Each of the
+// first 12 months has 30 days. The last "month", COMP, is the 5 or 6
+// complementary days at the end of the year.
+var frenchMonths = []calendarMonth{
+	{Token: "VEND", Names: []string{"VENDÉMIAIRE", "VENDEMIAIRE"}},
+	{Token: "BRUM", Names: []string{"BRUMAIRE"}},
+	{Token: "FRIM", Names: []string{"FRIMAIRE"}},
+	{Token: "NIVO", Names: []string{"NIVÔSE", "NIVOSE"}},
+	{Token: "PLUV", Names: []string{"PLUVIÔSE", "PLUVIOSE"}},
+	{Token: "VENT", Names: []string{"VENTÔSE", "VENTOSE"}},
+	{Token: "GERM", Names: []string{"GERMINAL"}},
+	{Token: "FLOR", Names: []string{"FLORÉAL", "FLOREAL"}},
+	{Token: "PRAI", Names: []string{"PRAIRIAL"}},
+	{Token: "MESS", Names: []string{"MESSIDOR"}},
+	{Token: "THER", Names: []string{"THERMIDOR"}},
+	{Token: "FRUC", Names: []string{"FRUCTIDOR"}},
+	{Token: "COMP", Names: []string{"SANSCULOTTIDES"}},
+}
+
+// frenchEpoch is the day number of 1 VEND I, which is 22 SEP 1792 in the
+// Gregorian calendar.
+const frenchEpoch = 2375840
+
+// frenchIsLeapYear reports whether or not the year has 6 complementary days.
+// While the calendar was in use, the leap years were III, VII, XI and XV. Later
+// years follow the rule proposed by Gilbert Romme, which is like the Gregorian
+// rule, with an extra exception for every 4000th year.
+func frenchIsLeapYear(y int) bool {
+	if y < 20 {
+		return y == 3 || y == 7 || y == 11 || y == 15
+	}
+	return y%4 == 0 && (y%100 != 0 || y%400 == 0) && y%4000 != 0
+}
+
+func frenchDaysInMonth(y int, m time.Month) int {
+	if m < 13 {
+		return 30
+	}
+	if frenchIsLeapYear(y) {
+		return 6
+	}
+	return 5
+}
+
+func frenchDaysInYear(y int) int {
+	if frenchIsLeapYear(y) {
+		return 366
+	}
+	return 365
+}
+
+func frenchToDayNumber(y int, m time.Month, d int) int {
+	out := frenchEpoch
+	for year := 1; year < y; year++ {
+		out += frenchDaysInYear(year)
+	}
+	return out + 30*(int(m)-1) + d - 1
+}
+
+func frenchFromDayNumber(jdn int) (y int, m time.Month, d int) {
+	y = 1
+	d = jdn - frenchEpoch + 1
+	for d < 1 {
+		y--
+		d += frenchDaysInYear(y)
+	}
+	for d > frenchDaysInYear(y) {
+		d -= frenchDaysInYear(y)
+		y++
+	}
+
+	m = time.Month((d-1)/30 + 1)
+	d -= 30 * (int(m) - 1)
+	return
+}
@@ -0,0 +1,143 @@
+package date
+
+import "time"
+
+// hebrewMonths are the months of the Hebrew calendar, in the order that they
+// occur within a year. The year begins with Tishrei. The month ADS (Adar Sheni,
+// or Adar II) only exists in leap years; in those years ADR is Adar I.
+var hebrewMonths = []calendarMonth{
+	{Token: "TSH", Names: []string{"TISHREI", "TISHRI"}},
+	{Token: "CSH", Names: []string{"CHESHVAN", "HESHVAN", "MARCHESHVAN"}},
+	{Token: "KSL", Names: []string{"KISLEV"}},
+	{Token: "TVT", Names: []string{"TEVET", "TEVETH"}},
+	{Token: "SHV", Names: []string{"SHEVAT", "SHVAT"}},
+	{Token: "ADR", Names: []string{"ADAR"}},
+	{Token: "ADS"},
+	{Token: "NSN", Names: []string{"NISAN"}},
+	{Token: "IYR", Names: []string{"IYAR"}},
+	{Token: "SVN", Names: []string{"SIVAN"}},
+	{Token: "TMZ", Names: []string{"TAMMUZ", "TAMUZ"}},
+	{Token: "AAV", Names: []string{"AV"}},
+	{Token: "ELL", Names: []string{"ELUL"}},
+}
+
+const (
+	hebrewCheshvan = time.Month(2)
+	hebrewKislev   = time.Month(3)
+	hebrewAdar     = time.Month(6)
+	hebrewAdarII   = time.Month(7)
+
+	// hebrewEpoch is the day number of 1 TSH 1, which is 7 OCT 3761 BCE in
+	// the proleptic Julian calendar.
+	hebrewEpoch = 347998
+)
+
+// hebrewIsLeapYear reports whether or not the year has 13 months. Leap years
+// are the 3rd, 6th, 8th, 11th, 14th, 17th and 19th years of a 19-year cycle.
+func hebrewIsLeapYear(y int) bool { return floorMod(7*y+1, 19) < 7 }
+
+func hebrewDaysInMonth(y int, m time.Month) int {
+	switch m {
+	case hebrewCheshvan:
+		// Cheshvan is 30 days in a "complete" year.
+		if hebrewDaysInYear(y)%10 == 5 {
+			return 30
+		}
+		return 29
+	case hebrewKislev:
+		// Kislev is 29 days in a "deficient" year.
+		if hebrewDaysInYear(y)%10 == 3 {
+			return 29
+		}
+		return 30
+	case hebrewAdar:
+		if hebrewIsLeapYear(y) {
+			return 30
+		}
+		return 29
+	case hebrewAdarII:
+		if hebrewIsLeapYear(y) {
+			return 29
+		}
+		return 0
+	}
+
+	// The remaining months alternate between 30 and 29 days, starting with
+	// Tishrei at 30 days. Adar II is ignored for this, so months after it are
+	// shifted by one.
+	n := int(m)
+	if m > hebrewAdarII {
+		n--
+	}
+	if n%2 == 1 {
+		return 30
+	}
+	return 29
+}
+
+// hebrewElapsedDays is the number of days from the epoch until the molad
+// (mean lunar conjunction) of Tishrei of year y, adjusted so that the new year
+// doesn't start on a Sunday, Wednesday or Friday. The algorithm is from
+// "Calendrical Calculations" by Dershowitz and Reingold.
+func hebrewElapsedDays(y int) int {
+	monthsElapsed := floorDiv(235*y-234, 19)
+	partsElapsed := 12084 + 13753*monthsElapsed
+	days := 29*monthsElapsed + floorDiv(partsElapsed, 25920)
+	if floorMod(3*(days+1), 7) < 3 {
+		days++
+	}
+	return days
+}
+
+// hebrewNewYear is the day number of 1 TSH of year y.
+func hebrewNewYear(y int) int {
+	ny0, ny1, ny2 := hebrewElapsedDays(y-1), hebrewElapsedDays(y), hebrewElapsedDays(y+1)
+
+	// Further postponements keep the year length within the allowed values.
+	var delay int
+	if ny2-ny1 == 356 {
+		delay = 2
+	} else if ny1-ny0 == 382 {
+		delay = 1
+	}
+	return hebrewEpoch + ny1 + delay
+}
+
+func hebrewDaysInYear(y int) int { return hebrewNewYear(y+1) - hebrewNewYear(y) }
+
+func hebrewToDayNumber(y int, m time.Month, d int) int {
+	out := hebrewNewYear(y)
+	for month := time.Month(1); month < m; month++ {
+		out += hebrewDaysInMonth(y, month)
+	}
+	return out + d - 1
+}
+
+func hebrewFromDayNumber(jdn int) (y int, m time.Month, d int) {
+	// Start with an estimate using the mean year length, then correct it.
+	y = floorDiv((jdn-hebrewEpoch)*98496, 35975351) + 1
+	for hebrewNewYear(y+1) <= jdn {
+		y++
+	}
+	for hebrewNewYear(y) > jdn {
+		y--
+	}
+
+	d = jdn - hebrewNewYear(y) + 1
+	for m = 1; d > hebrewDaysInMonth(y, m); m++ {
+		d -= hebrewDaysInMonth(y, m)
+	}
+	return
+}
+
+// floorDiv is integer division that rounds towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// floorMod is the remainder that goes with floorDiv, it has the same sign as b.
+func floorMod(a, b int) int { return a - b*floorDiv(a, b) }
@@ -0,0 +1,268 @@
+package date
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dates in GEDCOM files are not always in English, nor are they always
+// written with words. Some files from Spanish, German, Dutch and French
+// vendors, or people, have dates like "12 marzo 1890", "3. Mai 1901",
+// "ca. 1850", "vor 1800". Some others are numeric, like "1890-03-12" or
+// "12/03/1890". The localize function translates these into the English
+// tokens of the GEDCOM7 spec so that the rest of the package can work with
+// them.
+
+// NumericOrder is the order of the day and month in a numeric date, such as
+// "12/03/1890". It's only consulted when the input is ambiguous. For example,
+// "25/03/1890" could only be day first.
+type NumericOrder int
+
+const (
+	// DayFirst interprets "12/03/1890" as 12 MAR 1890. This is the default.
+	DayFirst NumericOrder = iota
+	// MonthFirst interprets "12/03/1890" as 3 DEC 1890.
+	MonthFirst
+)
+
+var numericOrderNames = map[NumericOrder]string{
+	DayFirst:   "DMY",
+	MonthFirst: "MDY",
+}
+
+func (o NumericOrder) String() string { return numericOrderNames[o] }
+
+// ParseNumericOrder interprets in as a NumericOrder. It's case-insensitive and
+// should be one of "DMY", "MDY".
+func ParseNumericOrder(in string) (NumericOrder, error) {
+	for order, name := range numericOrderNames {
+		if strings.EqualFold(in, name) {
+			return order, nil
+		}
+	}
+	return DayFirst, fmt.Errorf("invalid numeric order %q, should be one of %q", in, []string{DayFirst.String(), MonthFirst.String()})
+}
+
+// ParseOptions controls how ParseWithOptions interprets some ambiguous inputs.
+// The zero value is the default for Parse.
+type ParseOptions struct {
+	// NumericOrder is the order of the day and month in an ambiguous numeric
+	// date, such as "12/03/1890".
+	NumericOrder NumericOrder
+}
+
+// localMonths maps lower-cased month names, and their abbreviations, to the
+// Gregorian month. English names are not here, they're already understood.
+var localMonths = map[string]time.Month{
+	// Spanish
+	"enero": time.January, "ene": time.January,
+	"febrero": time.February,
+	"marzo":   time.March,
+	"abril":   time.April, "abr": time.April,
+	"mayo":   time.May,
+	"junio":  time.June,
+	"julio":  time.July,
+	"agosto": time.August, "ago": time.August,
+	"septiembre": time.September, "setiembre": time.September, "sept": time.September,
+	"octubre":   time.October,
+	"noviembre": time.November,
+	"diciembre": time.December, "dic": time.December,
+
+	// German
+	"januar": time.January, "jänner": time.January, "jän": time.January,
+	"februar": time.February,
+	"märz":    time.March, "maerz": time.March, "mär": time.March, "mrz": time.March,
+	"mai":     time.May,
+	"juni":    time.June,
+	"juli":    time.July,
+	"oktober": time.October, "okt": time.October,
+	"dezember": time.December, "dez": time.December,
+
+	// Dutch
+	"januari":  time.January,
+	"februari": time.February,
+	"maart":    time.March, "mrt": time.March,
+	"mei":      time.May,
+	"augustus": time.August,
+
+	// French
+	"janvier": time.January, "janv": time.January,
+	"février": time.February, "fevrier": time.February, "févr": time.February, "fevr": time.February,
+	"mars":  time.March,
+	"avril": time.April, "avr": time.April,
+	"juin":    time.June,
+	"juillet": time.July, "juil": time.July,
+	"août": time.August, "aout": time.August,
+	"septembre": time.September,
+	"octobre":   time.October,
+	"novembre":  time.November,
+	"décembre":  time.December, "decembre": time.December, "déc": time.December,
+}
+
+// localWords maps lower-cased approximation and range words to their GEDCOM7
+// tokens. Some of them are only meaningful after another token, see
+// localWordsAfter.
+var localWords = map[string]string{
+	// about
+	"circa": "ABT", "zirka": "ABT", "etwa": "ABT",
+	"hacia": "ABT", "aprox": "ABT", "aproximadamente": "ABT", "alrededor": "ABT",
+	"omstreeks": "ABT", "ongeveer": "ABT", "rond": "ABT",
+	"vers": "ABT", "environ": "ABT",
+	// calculated
+	"berechnet": "CAL", "calculado": "CAL", "calculada": "CAL", "berekend": "CAL",
+	"calculé": "CAL", "calculée": "CAL", "calcule": "CAL",
+	// estimated
+	"geschätzt": "EST", "geschaetzt": "EST", "estimado": "EST", "estimada": "EST",
+	"geschat": "EST", "estimé": "EST", "estimée": "EST", "estime": "EST",
+	// before
+	"antes": "BEF", "voor": "BEF", "avant": "BEF",
+	// after
+	"nach": "AFT", "después": "AFT", "despues": "AFT", "après": "AFT", "apres": "AFT",
+	// between
+	"zwischen": "BET", "entre": "BET", "tussen": "BET",
+	// from
+	"desde": "FROM", "depuis": "FROM",
+	// to
+	"hasta": "TO",
+}
+
+// localShortWords are like localWords, but they're short enough to be
+// English words, initials or abbreviations too, like "c" or "van". They're
+// only translated when the input can't be parsed without them.
+var localShortWords = map[string]string{
+	"ca": "ABT", "c": "ABT", "um": "ABT", "env": "ABT",
+	"vor": "BEF",
+	"na":  "AFT",
+	"von": "FROM", "van": "FROM", "du": "FROM",
+	"bis": "TO", "tot": "TO",
+}
+
+// localWordsAfter maps lower-cased words that are only translated when they
+// come after the key token. Otherwise, they're too ambiguous.
+var localWordsAfter = map[string]map[string]string{
+	rangeTokenBet:  {"und": "AND", "y": "AND", "e": "AND", "en": "AND", "et": "AND"},
+	rangeTokenFrom: {"a": "TO", "à": "TO", "au": "TO"},
+}
+
+// localFillers are lower-cased words that do not contribute to the meaning of
+// the date, such as "de" in "12 de marzo de 1890" or "antes de 1800".
+var localFillers = map[string]struct{}{"de": {}, "del": {}, "le": {}}
+
+var (
+	isoDate      = regexp.MustCompile(`^(\d{4})-(\d{1,2})(?:-(\d{1,2}))?$`)
+	numericDate  = regexp.MustCompile(`^(\d{1,2})[/.-](\d{1,2})[/.-](\d{3,4})$`)
+	numericMonth = regexp.MustCompile(`^(\d{1,2})[/.](\d{4})$`)
+	localOrdinal = regexp.MustCompile(`^(\d{1,2})(?:\.|er|e|º|°|ª)$`)
+)
+
+// localize translates the non-English words and numeric forms of the input
+// into GEDCOM7 tokens. Words that it does not recognize are left as is. The
+// localShortWords are only translated if short is true.
+func localize(in string, opts ParseOptions, short bool) string {
+	words := strings.Fields(strings.ReplaceAll(in, ",", " "))
+	out := make([]string, 0, len(words))
+	var first string // the translation of the first word, if any.
+
+	for i, word := range words {
+		lower := strings.ToLower(word)
+		bare := strings.TrimSuffix(lower, ".")
+
+		if i == 0 && bare == "de" && startsPeriod(words[1:]) {
+			first = rangeTokenFrom
+			out = append(out, first)
+			continue
+		}
+		if _, ok := localFillers[bare]; ok && i > 0 {
+			continue
+		}
+		if token, ok := localWordsAfter[first][bare]; ok && i > 0 {
+			out = append(out, token)
+			continue
+		}
+		token, ok := localWords[bare]
+		if !ok && short {
+			token, ok = localShortWords[bare]
+		}
+		if ok {
+			if i == 0 {
+				first = token
+			}
+			out = append(out, token)
+			continue
+		}
+		if month, ok := localMonths[bare]; ok {
+			out = append(out, Gregorian.monthToken(month))
+			continue
+		}
+		if m := localOrdinal.FindStringSubmatch(lower); m != nil {
+			out = append(out, m[1])
+			continue
+		}
+		if numeric, ok := localizeNumeric(word, opts.NumericOrder); ok {
+			out = append(out, numeric)
+			continue
+		}
+		if i == 0 {
+			first = strings.ToUpper(word)
+		}
+		out = append(out, word)
+	}
+
+	return strings.Join(out, " ")
+}
+
+// startsPeriod tells if the words, which come after a leading "de", look like
+// the rest of a period such as "de 1850 a 1860".
+func startsPeriod(words []string) bool {
+	for _, word := range words {
+		if _, ok := localWordsAfter[rangeTokenFrom][strings.ToLower(word)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// localizeNumeric translates a numeric date, such as "1890-03-12" or
+// "12/03/1890", into GEDCOM7 format, like "12 MAR 1890". The day and month of
+// a non-ISO date are interpreted by order, unless that would not make a valid
+// date.
+func localizeNumeric(in string, order NumericOrder) (string, bool) {
+	if m := isoDate.FindStringSubmatch(in); m != nil {
+		return formatNumeric(m[3], m[2], m[1])
+	}
+	if m := numericDate.FindStringSubmatch(in); m != nil {
+		day, month := m[1], m[2]
+		if order == MonthFirst {
+			day, month = month, day
+		}
+		if out, ok := formatNumeric(day, month, m[3]); ok {
+			return out, ok
+		}
+		// Be lenient, the other order might make sense.
+		return formatNumeric(month, day, m[3])
+	}
+	if m := numericMonth.FindStringSubmatch(in); m != nil {
+		return formatNumeric("", m[1], m[2])
+	}
+	return "", false
+}
+
+func formatNumeric(day, month, year string) (string, bool) {
+	m, err := strconv.Atoi(month)
+	if err != nil || m < 1 || m > 12 {
+		return "", false
+	}
+	parts := make([]string, 0, 3)
+	if day != "" {
+		d, err := strconv.Atoi(day)
+		if err != nil || d < 1 || d > 31 {
+			return "", false
+		}
+		parts = append(parts, strconv.Itoa(d))
+	}
+	parts = append(parts, Gregorian.monthToken(time.Month(m)), year)
+	return strings.Join(parts, " "), true
+}
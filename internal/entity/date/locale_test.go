@@ -0,0 +1,105 @@
+package date_test
+
+import (
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+)
+
+func TestParseLocalized(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{Name: "spanish month", Input: "12 marzo 1890", Expected: "12 MAR 1890"},
+		{Name: "spanish month with filler words", Input: "12 de marzo de 1890", Expected: "12 MAR 1890"},
+		{Name: "german month with day ending in a period", Input: "3. Mai 1901", Expected: "3 MAY 1901"},
+		{Name: "german umlaut", Input: "1. März 1850", Expected: "1 MAR 1850"},
+		{Name: "dutch month", Input: "5 mei 1870", Expected: "5 MAY 1870"},
+		{Name: "french month with ordinal", Input: "1er août 1880", Expected: "1 AUG 1880"},
+		{Name: "circa", Input: "ca. 1850", Expected: "ABT 1850"},
+		{Name: "german about", Input: "um 1850", Expected: "ABT 1850"},
+		{Name: "french about", Input: "vers 1850", Expected: "ABT 1850"},
+		{Name: "german estimated", Input: "geschätzt 1850", Expected: "EST 1850"},
+		{Name: "spanish calculated", Input: "calculado 1850", Expected: "CAL 1850"},
+		{Name: "german before", Input: "vor 1800", Expected: "BEF 1800"},
+		{Name: "spanish before", Input: "antes de 1800", Expected: "BEF 1800"},
+		{Name: "dutch after", Input: "na 1800", Expected: "AFT 1800"},
+		{Name: "french after", Input: "après le 3 mars 1800", Expected: "AFT 3 MAR 1800"},
+		{Name: "german between", Input: "zwischen 1850 und 1860", Expected: "BET 1850 AND 1860"},
+		{Name: "spanish between", Input: "entre 1850 y 1860", Expected: "BET 1850 AND 1860"},
+		{Name: "dutch between", Input: "tussen 1850 en 1860", Expected: "BET 1850 AND 1860"},
+		{Name: "german period", Input: "von 1850 bis 1860", Expected: "FROM 1850 TO 1860"},
+		{Name: "spanish period", Input: "de 1850 a 1860", Expected: "FROM 1850 TO 1860"},
+		{Name: "french period", Input: "du 3 octobre 1850 au 5 juin 1860", Expected: "FROM 3 OCT 1850 TO 5 JUN 1860"},
+		{Name: "iso", Input: "1890-03-12", Expected: "12 MAR 1890"},
+		{Name: "iso without day", Input: "1890-03", Expected: "MAR 1890"},
+		{Name: "numeric, day first", Input: "12/03/1890", Expected: "12 MAR 1890"},
+		{Name: "numeric with periods", Input: "12.03.1890", Expected: "12 MAR 1890"},
+		{Name: "numeric, only valid as month first", Input: "03/25/1890", Expected: "25 MAR 1890"},
+		{Name: "numeric month and year", Input: "03/1890", Expected: "MAR 1890"},
+		{Name: "approximate numeric", Input: "ca. 12/03/1890", Expected: "ABT 12 MAR 1890"},
+		{Name: "english is unaffected", Input: "Abt. March 3rd 1890", Expected: "ABT 3 MAR 1890"},
+		{Name: "dual year is unaffected", Input: "11 FEB 1731/32", Expected: "11 FEB 1732"},
+		{Name: "other calendars are unaffected", Input: "@#DFRENCH R@ 1 VEND 1", Expected: "FRENCH_R 1 VEND 1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dat, rng, err := date.Parse(test.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got, payload string
+			if dat != nil {
+				got, payload = dat.Format(), dat.Payload
+			} else {
+				got, payload = rng.Format(), rng.Payload
+			}
+			if got != test.Expected {
+				t.Errorf("wrong output; got %q, expected %q", got, test.Expected)
+			}
+			if payload != test.Input {
+				t.Errorf("wrong Payload; got %q, expected %q", payload, test.Input)
+			}
+		})
+	}
+}
+
+func TestParseNumericOrder(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Order    date.NumericOrder
+		Input    string
+		Expected string
+	}{
+		{Name: "day first", Order: date.DayFirst, Input: "12/03/1890", Expected: "12 MAR 1890"},
+		{Name: "month first", Order: date.MonthFirst, Input: "12/03/1890", Expected: "3 DEC 1890"},
+		{Name: "month first, only valid as day first", Order: date.MonthFirst, Input: "25/03/1890", Expected: "25 MAR 1890"},
+		{Name: "month first, iso is unaffected", Order: date.MonthFirst, Input: "1890-03-12", Expected: "12 MAR 1890"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			order, err := date.ParseNumericOrder(test.Order.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dat, _, err := date.ParseWithOptions(test.Input, date.ParseOptions{NumericOrder: order})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := dat.Format()
+			if got != test.Expected {
+				t.Errorf("wrong output; got %q, expected %q", got, test.Expected)
+			}
+		})
+	}
+
+	if _, err := date.ParseNumericOrder("YMD"); err == nil {
+		t.Error("expected an error for an unknown order")
+	}
+}
@@ -0,0 +1,150 @@
+package date
+
+import "cmp"
+
+// Order is the outcome of comparing two values whose exact dates may be
+// unknown. It's three-valued because uncertain values cannot always be put in
+// order. For example, "ABT 1850" could be before or after "3 MAR 1850".
+type Order int
+
+const (
+	// PossiblyEither means that the values could be in either order, or on
+	// the same day. It's also the Order when there is not enough information,
+	// such as a phrase-only Date.
+	PossiblyEither Order = 0
+	// DefinitelyBefore means that the first value ends before the second value
+	// could begin.
+	DefinitelyBefore Order = -1
+	// DefinitelyAfter means that the first value begins after the second value
+	// could end.
+	DefinitelyAfter Order = 1
+)
+
+func (o Order) String() string {
+	switch o {
+	case DefinitelyBefore:
+		return "definitely before"
+	case DefinitelyAfter:
+		return "definitely after"
+	default:
+		return "possibly either"
+	}
+}
+
+// Compare determines if a is definitely before b, definitely after b, or if
+// it's not possible to tell. A Date without a day or a month spans the whole
+// month or year. A Range spans from its Lo to its Hi, and a missing bound, such
+// as the Hi of "AFT 1850", is open ended.
+func Compare(a, b Value) Order {
+	aLo, aHi, aHasLo, aHasHi := a.dayInterval()
+	bLo, bHi, bHasLo, bHasHi := b.dayInterval()
+
+	if aHasHi && bHasLo && aHi < bLo {
+		return DefinitelyBefore
+	}
+	if aHasLo && bHasHi && aLo > bHi {
+		return DefinitelyAfter
+	}
+	return PossiblyEither
+}
+
+// CmpValues compares a and b for sorting. It returns
+//
+//	-1 if a < b.
+//	 0 if a == b.
+//	+1 if a > b.
+//
+// It agrees with Compare whenever Compare has a definite answer. Otherwise, it
+// orders by the earliest possible day, then by the latest possible day. A
+// missing bound is substituted with the other one, so "BEF 1900" is sorted by
+// the end of 1900. A value without any dates at all is greater than any value
+// with dates.
+func CmpValues(a, b Value) int {
+	aLo, aHi, aOK := sortingInterval(a)
+	bLo, bHi, bOK := sortingInterval(b)
+	if !aOK || !bOK {
+		return cmpBools(!aOK, !bOK)
+	}
+
+	if c := cmp.Compare(aLo, bLo); c != 0 {
+		return c
+	}
+	return cmp.Compare(aHi, bHi)
+}
+
+func sortingInterval(v Value) (lo, hi int, ok bool) {
+	lo, hi, hasLo, hasHi := v.dayInterval()
+	switch {
+	case hasLo && hasHi:
+	case hasLo:
+		hi = lo
+	case hasHi:
+		lo = hi
+	default:
+		return
+	}
+	ok = true
+	return
+}
+
+// Overlaps tells if r and v could share at least one day. A missing bound of r,
+// such as the Lo of "BEF 1850", is open ended.
+func (r *Range) Overlaps(v Value) bool {
+	return Compare(r, v) == PossiblyEither && hasInterval(r) && hasInterval(v)
+}
+
+// Contains tells if every possible day of v is within r. A missing bound of r
+// is open ended, but a missing bound of v is not contained by anything except
+// another open end.
+func (r *Range) Contains(v Value) bool {
+	rLo, rHi, rHasLo, rHasHi := r.dayInterval()
+	vLo, vHi, vHasLo, vHasHi := v.dayInterval()
+	if !hasInterval(r) || !(vHasLo || vHasHi) {
+		return false
+	}
+
+	if rHasLo && (!vHasLo || vLo < rLo) {
+		return false
+	}
+	if rHasHi && (!vHasHi || vHi > rHi) {
+		return false
+	}
+	return true
+}
+
+// Intersect is the Range of days shared by r and other. The output bounds are
+// the later of the Lo fields and the earlier of the Hi fields. It's nil if the
+// Ranges do not overlap.
+func (r *Range) Intersect(other *Range) *Range {
+	if !r.Overlaps(other) {
+		return nil
+	}
+
+	out := &Range{Lo: r.Lo, Hi: r.Hi}
+	if r.Lo == nil || r.Lo.PhraseOnly() {
+		out.Lo = other.Lo
+	} else if other.Lo != nil && !other.Lo.PhraseOnly() {
+		rLo, _ := r.Lo.dayBounds()
+		otherLo, _ := other.Lo.dayBounds()
+		if otherLo > rLo {
+			out.Lo = other.Lo
+		}
+	}
+	if r.Hi == nil || r.Hi.PhraseOnly() {
+		out.Hi = other.Hi
+	} else if other.Hi != nil && !other.Hi.PhraseOnly() {
+		_, rHi := r.Hi.dayBounds()
+		_, otherHi := other.Hi.dayBounds()
+		if otherHi < rHi {
+			out.Hi = other.Hi
+		}
+	}
+
+	out.Payload = out.Format()
+	return out
+}
+
+func hasInterval(v Value) bool {
+	_, _, hasLo, hasHi := v.dayInterval()
+	return hasLo || hasHi
+}
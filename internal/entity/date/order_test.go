@@ -0,0 +1,150 @@
+package date_test
+
+import (
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		Name     string
+		A, B     string
+		Expected date.Order
+	}{
+		{Name: "exact dates", A: "2 MAR 1850", B: "3 MAR 1850", Expected: date.DefinitelyBefore},
+		{Name: "exact dates, reversed", A: "3 MAR 1850", B: "2 MAR 1850", Expected: date.DefinitelyAfter},
+		{Name: "same day", A: "3 MAR 1850", B: "3 MAR 1850", Expected: date.PossiblyEither},
+		{Name: "year contains day", A: "ABT 1850", B: "3 MAR 1850", Expected: date.PossiblyEither},
+		{Name: "month contains day", A: "MAR 1850", B: "3 MAR 1850", Expected: date.PossiblyEither},
+		{Name: "different years", A: "1849", B: "3 MAR 1850", Expected: date.DefinitelyBefore},
+		{Name: "overlapping ranges", A: "BET 1840 AND 1850", B: "BET 1845 AND 1860", Expected: date.PossiblyEither},
+		{Name: "disjoint ranges", A: "BET 1840 AND 1844", B: "BET 1845 AND 1860", Expected: date.DefinitelyBefore},
+		{Name: "open ended before", A: "BEF 1850", B: "1850", Expected: date.PossiblyEither},
+		{Name: "open ended before, earlier", A: "BEF 1849", B: "1850", Expected: date.DefinitelyBefore},
+		{Name: "open ended after", A: "AFT 1850", B: "1849", Expected: date.DefinitelyAfter},
+		{Name: "open ended on both sides", A: "AFT 1850", B: "BEF 1900", Expected: date.PossiblyEither},
+		{Name: "different calendars", A: "JULIAN 5 OCT 1582", B: "16 OCT 1582", Expected: date.DefinitelyBefore},
+		{Name: "phrase only", A: "(the winter after the flood)", B: "1850", Expected: date.PossiblyEither},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := date.Compare(mustParseValue(t, test.A), mustParseValue(t, test.B))
+			if got != test.Expected {
+				t.Errorf("wrong output; got %q, expected %q", got, test.Expected)
+			}
+		})
+	}
+}
+
+func TestCmpValues(t *testing.T) {
+	tests := []struct {
+		Name     string
+		A, B     string
+		Expected int
+	}{
+		{Name: "definitely before", A: "1849", B: "1850", Expected: -1},
+		{Name: "year before day in that year", A: "ABT 1850", B: "3 MAR 1850", Expected: -1},
+		{Name: "day in year after year", A: "3 MAR 1850", B: "1850", Expected: 1},
+		{Name: "same", A: "MAR 1850", B: "MAR 1850", Expected: 0},
+		{Name: "wider range is after", A: "1850", B: "BET 1850 AND 1851", Expected: -1},
+		{Name: "open ended before sorts by its end", A: "1849", B: "BEF 1850", Expected: -1},
+		{Name: "open ended after sorts by its start", A: "AFT 1850", B: "1851", Expected: -1},
+		{Name: "phrase only is last", A: "(sometime)", B: "1850", Expected: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := date.CmpValues(mustParseValue(t, test.A), mustParseValue(t, test.B))
+			if got != test.Expected {
+				t.Errorf("wrong output; got %d, expected %d", got, test.Expected)
+			}
+		})
+	}
+}
+
+func TestRangeOverlaps(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Range    string
+		Other    string
+		Expected bool
+	}{
+		{Name: "overlapping ranges", Range: "BET 1840 AND 1850", Other: "BET 1845 AND 1860", Expected: true},
+		{Name: "touching ranges", Range: "BET 1840 AND 1850", Other: "FROM 1850 TO 1860", Expected: true},
+		{Name: "disjoint ranges", Range: "BET 1840 AND 1844", Other: "BET 1845 AND 1860", Expected: false},
+		{Name: "date within", Range: "FROM 1840 TO 1850", Other: "3 MAR 1845", Expected: true},
+		{Name: "date outside", Range: "FROM 1840 TO 1850", Other: "3 MAR 1855", Expected: false},
+		{Name: "open ended", Range: "AFT 1840", Other: "1900", Expected: true},
+		{Name: "phrase only", Range: "AFT 1840", Other: "(sometime)", Expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := mustParseRange(t, test.Range).Overlaps(mustParseValue(t, test.Other))
+			if got != test.Expected {
+				t.Errorf("wrong output; got %t, expected %t", got, test.Expected)
+			}
+		})
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Range    string
+		Other    string
+		Expected bool
+	}{
+		{Name: "date within", Range: "FROM 1840 TO 1850", Other: "3 MAR 1845", Expected: true},
+		{Name: "year at the edge", Range: "FROM 1840 TO 1850", Other: "1850", Expected: true},
+		{Name: "date outside", Range: "FROM 1840 TO 1850", Other: "3 MAR 1855", Expected: false},
+		{Name: "range within", Range: "BET 1840 AND 1850", Other: "BET 1842 AND 1848", Expected: true},
+		{Name: "overlapping range", Range: "BET 1840 AND 1850", Other: "BET 1845 AND 1860", Expected: false},
+		{Name: "open ended contains range", Range: "AFT 1840", Other: "BET 1845 AND 1860", Expected: true},
+		{Name: "open ended contains open ended", Range: "AFT 1840", Other: "AFT 1850", Expected: true},
+		{Name: "closed does not contain open ended", Range: "BET 1840 AND 1850", Other: "AFT 1845", Expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := mustParseRange(t, test.Range).Contains(mustParseValue(t, test.Other))
+			if got != test.Expected {
+				t.Errorf("wrong output; got %t, expected %t", got, test.Expected)
+			}
+		})
+	}
+}
+
+func TestRangeIntersect(t *testing.T) {
+	tests := []struct {
+		Name     string
+		A, B     string
+		Expected string
+	}{
+		{Name: "overlapping ranges", A: "BET 1840 AND 1850", B: "BET 1845 AND 1860", Expected: "BET 1845 AND 1850"},
+		{Name: "one within the other", A: "BET 1840 AND 1850", B: "BET 1842 AND 1848", Expected: "BET 1842 AND 1848"},
+		{Name: "open ended", A: "AFT 1840", B: "BEF 1850", Expected: "BET 1840 AND 1850"},
+		{Name: "same open end", A: "BEF 1840", B: "BEF 1850", Expected: "BEF 1840"},
+		{Name: "disjoint ranges", A: "BET 1840 AND 1844", B: "BET 1845 AND 1860", Expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := mustParseRange(t, test.A).Intersect(mustParseRange(t, test.B))
+			if test.Expected == "" {
+				if got != nil {
+					t.Fatalf("expected nil, got %q", got.Payload)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("unexpected nil output")
+			}
+			if got.Payload != test.Expected {
+				t.Errorf("wrong output; got %q, expected %q", got.Payload, test.Expected)
+			}
+		})
+	}
+}
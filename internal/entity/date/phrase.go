@@ -0,0 +1,83 @@
+package date
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A date phrase is free text that describes a date. In GEDCOM 5.5.1, it's part
+// of the date value itself and is enclosed in parentheses. Some examples:
+//
+//	(Spring of 1850)
+//	INT 1850 (about the time of the war)
+//
+// The first example is a phrase-only date. The second is an interpreted date,
+// where the phrase is the original text and the date is an interpretation of
+// it. In GEDCOM7, the phrase is a PHRASE substructure of the DATE instead, so
+// it is not handled here; see the SetPhrase methods on Date and Range.
+
+const interpretedToken = "INT"
+
+var errNotPhrase = errors.New("input does not appear to have a phrase at all")
+
+// NewPhrase makes a phrase-only Date, which keeps a textual date that cannot be
+// interpreted as a date, such as "unknown". Its Year, Month, Day fields are 0.
+func NewPhrase(phrase string) *Date {
+	out := &Date{Payload: phrase, Phrase: strings.TrimSpace(phrase)}
+	out.setDisplay()
+	return out
+}
+
+// PhraseOnly reports whether or not the Date only has a phrase, rather than a
+// value for any of Year, Month, Day fields.
+func (d *Date) PhraseOnly() bool { return d.Year == 0 && d.Phrase != "" }
+
+// SetPhrase sets the Phrase field and updates the Display.
+func (d *Date) SetPhrase(phrase string) {
+	d.Phrase = phrase
+	d.setDisplay()
+}
+
+// SetPhrase sets the Phrase field.
+func (r *Range) SetPhrase(phrase string) { r.Phrase = phrase }
+
+// parsePhrase attempts to interpret in as a phrase-only date, or an
+// interpreted date. If the input does not have a phrase in the first place,
+// then err is errNotPhrase.
+func parsePhrase(in string, opts ParseOptions) (out *Date, err error) {
+	trimmed := strings.TrimSpace(in)
+	if strings.HasPrefix(trimmed, "(") && strings.HasSuffix(trimmed, ")") {
+		out = NewPhrase(trimmed[1 : len(trimmed)-1])
+		out.Payload = in
+		return
+	}
+
+	token, rest, found := strings.Cut(trimmed, " ")
+	if !found || strings.ToUpper(token) != interpretedToken {
+		err = errNotPhrase
+		return
+	}
+
+	value, phrase, found := strings.Cut(rest, "(")
+	if !found || !strings.HasSuffix(phrase, ")") {
+		err = fmt.Errorf("malformatted interpreted date %q, phrase should be enclosed in parentheses", in)
+		return
+	}
+
+	localized := localize(value, opts, false)
+	if out, err = parseDateWithApproximationToken(localized); err != nil {
+		short := localize(value, opts, true)
+		if short == localized {
+			return nil, err
+		}
+		if out, err = parseDateWithApproximationToken(short); err != nil {
+			return nil, err
+		}
+	}
+	out.Payload = in
+	out.Approximate = true
+	out.Qualifier = interpretedToken
+	out.SetPhrase(strings.TrimSpace(phrase[:len(phrase)-1]))
+	return
+}
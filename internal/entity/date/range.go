@@ -2,6 +2,7 @@ package date
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -21,8 +22,14 @@ import (
 // that it is case-insensitive and will allow for expanded versions of said
 // approximation words.
 type Range struct {
-	Lo, Hi  *Date
+	Lo, Hi *Date
+	// Period indicates that the Range is a DatePeriod, which is written with
+	// FROM and TO. Otherwise, it's a dateRange, written with BET, AND, AFT,
+	// BEF.
+	Period  bool
 	Payload string
+	// Phrase is free text about the Range, such as "during the war".
+	Phrase string
 }
 
 var errNotRange = errors.New("input does not appear to be a range at all")
@@ -43,7 +50,8 @@ func parseRange(in string) (out *Range, err error) {
 	uin := strings.ToUpper(in)
 
 	// First, attempt to interpret like a GEDCOM7 "DatePeriod"
-	toInd := strings.Index(uin, rangeTokenTo)
+	toInd := indexWord(uin, rangeTokenTo)
+	out.Period = toInd == 0 || strings.HasPrefix(uin, rangeTokenFrom)
 	if toInd == 0 {
 		out.Hi, err = parseDateWithoutApproximationToken(in[len(rangeTokenTo)+1:])
 		if err == nil {
@@ -72,7 +80,7 @@ func parseRange(in string) (out *Range, err error) {
 	}
 
 	// Attempt to interpret like a GEDCOM7 "dateRange"
-	andInd := strings.Index(uin, rangeTokenAnd)
+	andInd := indexWord(uin, rangeTokenAnd)
 	for _, token := range []string{rangeTokenBet + "WEEN", rangeTokenBet + ".", rangeTokenBet} {
 		if strings.HasPrefix(uin, token) && andInd > 0 {
 			if out.Lo, err = parseDateWithoutApproximationToken(in[len(token)+1 : andInd-1]); err != nil {
@@ -112,7 +120,43 @@ func parseRange(in string) (out *Range, err error) {
 	return
 }
 
+// indexWord is like strings.Index, but only matches word as a whole word. That
+// way, the "TO" within "OCTOBER" is not mistaken for the token "TO".
+func indexWord(s, word string) int {
+	for offset := 0; offset < len(s); {
+		ind := strings.Index(s[offset:], word)
+		if ind < 0 {
+			return -1
+		}
+		ind += offset
+		end := ind + len(word)
+		if (ind == 0 || s[ind-1] == ' ') && (end == len(s) || s[end] == ' ') {
+			return ind
+		}
+		offset = end
+	}
+	return -1
+}
+
 func parseDateWithoutApproximationToken(in string) (out *Date, err error) {
+	out = &Date{}
+	out.Calendar, in = splitCalendar(normalizeSpelling(in))
+	out.BCE, in = splitEpoch(in)
+	if out.DualYear, in, err = splitDualYear(in); err != nil {
+		return nil, err
+	}
+	if (out.BCE || out.DualYear != 0) && !out.Calendar.hasEpochs() {
+		return nil, fmt.Errorf("epoch and dual years are not applicable to the %s calendar", out.Calendar.displayName())
+	}
+
+	if out.Calendar != Gregorian || out.BCE || out.DualYear != 0 {
+		if err = parseCalendarDate(out, in); err != nil {
+			return nil, err
+		}
+		out.setDisplay()
+		return
+	}
+
 	errs := make([]error, 0, len(dateLayouts))
 	for _, layout := range dateLayouts {
 		out, err = parse(layout, in)
@@ -124,6 +168,14 @@ func parseDateWithoutApproximationToken(in string) (out *Date, err error) {
 		}
 	}
 
+	// The layouts only work with 4-digit years, such as "1066". Medieval and
+	// older dates, such as "800" do not fit.
+	out = &Date{}
+	if err = parseCalendarDate(out, in); err == nil {
+		out.setDisplay()
+		return
+	}
+
 	err = errors.Join(errs...)
-	return
+	return nil, err
 }
@@ -113,6 +113,14 @@ func TestParseRange(t *testing.T) {
 					Hi: &date.Date{Year: 2038, Month: time.January, Day: 17, Display: "2038-01-17"},
 				},
 			},
+			{
+				Name:  "month name contains TO",
+				Input: "FROM 2 OCTOBER 2006 TO 17 OCTOBER 2038",
+				Expected: &date.Range{
+					Lo: &date.Date{Year: 2006, Month: time.October, Day: 2, Display: "2006-10-02"},
+					Hi: &date.Date{Year: 2038, Month: time.October, Day: 17, Display: "2038-10-17"},
+				},
+			},
 			{
 				Name:  "all parts present, tokens mixed case",
 				Input: "From 2 Jan 2006 To 17 Jan 2038",
@@ -1,7 +1,9 @@
 package entity_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/rafaelespinoza/ged/internal/entity"
 	"github.com/rafaelespinoza/ged/internal/entity/date"
@@ -52,6 +54,20 @@ func TestDate(t *testing.T) {
 			},
 			ExpString: "<= 2038-01-19",
 		},
+		{
+			Name: "Range with phrase",
+			InRange: &date.Range{
+				Lo:     &date.Date{Display: "1850"},
+				Hi:     &date.Date{Display: "1860"},
+				Phrase: "during the war",
+			},
+			ExpString: "1850 ... 1860 (during the war)",
+		},
+		{
+			Name:      "phrase only",
+			InDate:    date.NewPhrase("unknown"),
+			ExpString: "(unknown)",
+		},
 	}
 
 	for _, test := range tests {
@@ -77,3 +93,77 @@ func TestDate(t *testing.T) {
 		})
 	}
 }
+
+func TestNewDuration(t *testing.T) {
+	birth, err := entity.NewDate(&date.Date{Year: 1850, Month: time.March, Day: 3}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	death, err := entity.NewDate(nil, &date.Range{Lo: &date.Date{Year: 1921}, Hi: &date.Date{Year: 1922}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := entity.NewDuration(birth, death)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "between 70 and 72 years" {
+		t.Errorf("wrong String; got %q, expected %q", got.String(), "between 70 and 72 years")
+	}
+
+	if _, err = entity.NewDuration(birth, &entity.Date{}); err == nil {
+		t.Error("expected error for empty input")
+	}
+	if _, err = entity.NewDuration(nil, death); err == nil {
+		t.Error("expected error for nil input")
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	t.Run("EDTF is encoded", func(t *testing.T) {
+		dat, _, err := date.Parse("ABT 3 MAR 1850")
+		if err != nil {
+			t.Fatal(err)
+		}
+		in, err := entity.NewDate(dat, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		raw, err := json.Marshal(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got entity.Date
+		if err = json.Unmarshal(raw, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.EDTF != "1850-03-03~" {
+			t.Errorf("wrong EDTF; got %q, expected %q", got.EDTF, "1850-03-03~")
+		}
+		if got.Date == nil || got.Date.Year != 1850 {
+			t.Errorf("wrong Date; got %#v", got.Date)
+		}
+	})
+
+	t.Run("only EDTF is decoded", func(t *testing.T) {
+		var got entity.Date
+		if err := json.Unmarshal([]byte(`{"EDTF": "[1850..1860]"}`), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Range == nil {
+			t.Fatal("expected non-empty Range")
+		}
+		if got.Range.Format() != "BET 1850 AND 1860" {
+			t.Errorf("wrong Range; got %q, expected %q", got.Range.Format(), "BET 1850 AND 1860")
+		}
+	})
+
+	t.Run("invalid EDTF", func(t *testing.T) {
+		var got entity.Date
+		if err := json.Unmarshal([]byte(`{"EDTF": "1850-13"}`), &got); err == nil {
+			t.Fatal("expected error but got nil")
+		}
+	})
+}
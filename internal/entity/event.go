@@ -0,0 +1,23 @@
+package entity
+
+// An Event is something that happened in the life of a Person, such as a
+// birth, a baptism or a residence.
+type Event struct {
+	// Type describes the event, such as "Birth" or "Residence".
+	Type  string
+	Date  *Date    `json:",omitempty"`
+	Place string   `json:",omitempty"`
+	Notes []string `json:",omitempty"`
+}
+
+// An Attribute is a characteristic of a Person, such as an occupation or a
+// religion. It may also have a date and a place, like an Event.
+type Attribute struct {
+	// Type describes the attribute, such as "Occupation" or "Religion".
+	Type string
+	// Value is the attribute itself, such as "Teacher" for an occupation.
+	Value string
+	Date  *Date    `json:",omitempty"`
+	Place string   `json:",omitempty"`
+	Notes []string `json:",omitempty"`
+}
@@ -5,9 +5,70 @@ package entity
 type Person struct {
 	ID        string
 	Name      PersonalName
+	Sex       Sex `json:",omitempty"`
 	Birthdate *Date
 	Deathdate *Date
-	Parents   []*Person
-	Children  []*Person
-	Spouses   []*Person
+	// EstimatedBirthdate and EstimatedDeathdate are inferred from the dates of
+	// relatives when the Birthdate or Deathdate is unknown. They are separate
+	// so that an estimate never replaces known data.
+	EstimatedBirthdate *Date `json:",omitempty"`
+	EstimatedDeathdate *Date `json:",omitempty"`
+	// LivingStatus is inferred from the dates of the person and their
+	// relatives. A missing death date alone does not mean that the person is
+	// living.
+	LivingStatus LivingStatus `json:",omitempty"`
+	// Events are everything that happened in the life of the person, in
+	// chronological order. The birth and death are here too, along with their
+	// places.
+	Events     []*Event     `json:",omitempty"`
+	Attributes []*Attribute `json:",omitempty"`
+	Notes      []string     `json:",omitempty"`
+	Parents    []*Person
+	// ParentPedigrees tell how the person is a child of each parent, keyed by
+	// the parent ID. Only the parents who are not known to be birth parents
+	// are in here.
+	ParentPedigrees map[string]Pedigree `json:",omitempty"`
+	Children        []*Person
+	Spouses         []*Person
 }
+
+// Sex is the biological sex of a Person, as recorded in the data. The values
+// are the same as the GEDCOM tag SEX.
+type Sex string
+
+const (
+	Male       = Sex("M")
+	Female     = Sex("F")
+	NeitherSex = Sex("X")
+	SexUnknown = Sex("U")
+)
+
+// Pedigree is how a child belongs to a parent. The values are the same as the
+// GEDCOM tag PEDI. It's empty if it's unknown, which usually means a birth.
+type Pedigree string
+
+const (
+	BirthPedigree = Pedigree("BIRTH")
+	Adopted       = Pedigree("ADOPTED")
+	Foster        = Pedigree("FOSTER")
+	Sealing       = Pedigree("SEALING")
+	OtherPedigree = Pedigree("OTHER")
+)
+
+// IsBirth tells if the child is presumed to be a biological child.
+func (p Pedigree) IsBirth() bool { return p == "" || p == BirthPedigree }
+
+// IsLiving is the policy for features that should protect the privacy of
+// living people. A Person is treated as living unless they are known to be
+// deceased, because it's safer to hide too much than too little.
+func (p *Person) IsLiving() bool { return p.LivingStatus != Deceased }
+
+// LivingStatus tells if a Person is presumed to be living, is deceased, or if
+// it's unknown.
+type LivingStatus string
+
+const (
+	LivingUnknown = LivingStatus("unknown")
+	Living        = LivingStatus("living")
+	Deceased      = LivingStatus("deceased")
+)
@@ -8,13 +8,18 @@ package entity
 // ancestral path to the CommonPerson, where the first person in the Path is
 // starting person and the last person is the CommonPerson.
 //
+// CommonAncestors are the ancestral couple that the relationship runs through.
+// It has only one person, the CommonPerson, if the relationship is half-blood,
+// or if the other member of the couple is not known.
+//
 // If person A and person B are related by marriage, then Union is non-empty.
 // The Path fields in each Relationship describe how the starting person relates
 // to a person in the Union.
 type MutualRelationship struct {
-	CommonPerson *Person
-	Union        *Union
-	R1, R2       Relationship
+	CommonPerson    *Person
+	CommonAncestors []*Person
+	Union           *Union
+	R1, R2          Relationship
 }
 
 // Relationship is a unidirectional descriptor how a person at SourceID relates
@@ -29,7 +34,11 @@ type Relationship struct {
 	// affinal (by law, or via marriage), or is unknown.
 	Type RelationshipType
 	// Description elaborates on the Type.
-	Description        string
+	Description string
+	// Pedigree is not a birth if any parent and child along the way are not
+	// related by birth. Then, the Description says so, like "adoptive parent"
+	// or "foster sibling". A foster link outranks an adopted one.
+	Pedigree           Pedigree `json:",omitempty"`
 	GenerationsRemoved int
 	// Path is the path to a common person if the Type field indicates a
 	// consanguineous relationship. If the Type indiciates an affinal
@@ -60,6 +69,20 @@ const (
 	AuntUncleInLaw
 	CousinInLaw
 	NieceNephewInLaw
+
+	// These values indicate a consanguineous relationship through only one
+	// member of the ancestral couple, such as siblings with one parent in
+	// common.
+	HalfSibling
+	HalfAuntUncle
+	HalfCousin
+	HalfNieceNephew
+
+	// These values indicate a relationship through the union of a parent with
+	// someone who is not the other parent.
+	StepParent
+	StepChild
+	StepSibling
 )
 
 var relationshipNames = []string{
@@ -80,6 +103,41 @@ var relationshipNames = []string{
 	"aunt/uncle in-law",
 	"cousin in-law",
 	"niece/nephew in-law",
+
+	"half sibling",
+	"half aunt/uncle",
+	"half cousin",
+	"half niece/nephew",
+
+	"step-parent",
+	"step-child",
+	"step-sibling",
+}
+
+// genderedNames are the names of some relationship types for a male, then a
+// female.
+var genderedNames = map[RelationshipType][2]string{
+	Sibling:          {"brother", "sister"},
+	Child:            {"son", "daughter"},
+	Parent:           {"father", "mother"},
+	AuntUncle:        {"uncle", "aunt"},
+	NieceNephew:      {"nephew", "niece"},
+	Spouse:           {"husband", "wife"},
+	SiblingInLaw:     {"brother in-law", "sister in-law"},
+	ChildInLaw:       {"son in-law", "daughter in-law"},
+	ParentInLaw:      {"father in-law", "mother in-law"},
+	AuntUncleInLaw:   {"uncle in-law", "aunt in-law"},
+	NieceNephewInLaw: {"nephew in-law", "niece in-law"},
+	StepParent:       {"step-father", "step-mother"},
+	StepChild:        {"step-son", "step-daughter"},
+	StepSibling:      {"step-brother", "step-sister"},
+}
+
+var halfTypes = map[RelationshipType]RelationshipType{
+	Sibling:     HalfSibling,
+	AuntUncle:   HalfAuntUncle,
+	Cousin:      HalfCousin,
+	NieceNephew: HalfNieceNephew,
 }
 
 func (t RelationshipType) String() string {
@@ -89,3 +147,47 @@ func (t RelationshipType) String() string {
 
 	return relationshipNames[t]
 }
+
+// Term is the name of t for a person of the given sex, like "aunt" or "uncle".
+// If the sex is not male or female, or if t has no gendered names, then it's
+// the same as String.
+func (t RelationshipType) Term(sex Sex) string {
+	if t.IsHalf() {
+		return "half " + t.Whole().Term(sex)
+	}
+
+	names, ok := genderedNames[t]
+	switch {
+	case ok && sex == Male:
+		return names[0]
+	case ok && sex == Female:
+		return names[1]
+	default:
+		return t.String()
+	}
+}
+
+// Half is the half-blood version of t. If there is none, then it's t.
+func (t RelationshipType) Half() RelationshipType {
+	if half, ok := halfTypes[t]; ok {
+		return half
+	}
+	return t
+}
+
+// IsHalf tells if t is a half-blood relationship.
+func (t RelationshipType) IsHalf() bool { return t >= HalfSibling && t <= HalfNieceNephew }
+
+// IsStep tells if t is a step relationship.
+func (t RelationshipType) IsStep() bool { return t >= StepParent && t <= StepSibling }
+
+// Whole is the full-blood version of t. If t is not a half-blood relationship,
+// then it's t.
+func (t RelationshipType) Whole() RelationshipType {
+	for whole, half := range halfTypes {
+		if half == t {
+			return whole
+		}
+	}
+	return t
+}
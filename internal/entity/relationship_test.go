@@ -0,0 +1,31 @@
+package entity
+
+import "testing"
+
+func TestRelationshipTypeTerm(t *testing.T) {
+	tests := []struct {
+		In  RelationshipType
+		Sex Sex
+		Exp string
+	}{
+		{In: AuntUncle, Sex: Male, Exp: "uncle"},
+		{In: AuntUncle, Sex: Female, Exp: "aunt"},
+		{In: AuntUncle, Sex: NeitherSex, Exp: "aunt/uncle"},
+		{In: AuntUncle, Sex: SexUnknown, Exp: "aunt/uncle"},
+		{In: AuntUncle, Exp: "aunt/uncle"},
+		{In: ChildInLaw, Sex: Female, Exp: "daughter in-law"},
+		{In: HalfSibling, Sex: Male, Exp: "half brother"},
+		{In: HalfSibling, Exp: "half sibling"},
+		{In: Cousin, Sex: Female, Exp: "cousin"},
+		{In: Self, Sex: Male, Exp: "self"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.In.String()+" "+string(test.Sex), func(t *testing.T) {
+			got := test.In.Term(test.Sex)
+			if got != test.Exp {
+				t.Errorf("wrong output; got %q, expected %q", got, test.Exp)
+			}
+		})
+	}
+}
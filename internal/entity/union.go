@@ -1,11 +1,58 @@
 package entity
 
-// A Union is a relationship between two people, usually resulting in children.
+// A Union is a relationship between people, usually resulting in children. It
+// usually has two partners, but it could have only one if the other is unknown.
+// Some data also has more than two partners in a Union.
 type Union struct {
 	ID        string
-	Person1   *Person
-	Person2   *Person
+	Partners  []*Partner
 	StartDate *Date
 	EndDate   *Date
 	Children  []*Person
 }
+
+// A Partner is a Person in a Union, along with their role in it.
+type Partner struct {
+	Person *Person
+	Role   PartnerRole `json:",omitempty"`
+}
+
+// PartnerRole is the role of a Partner in a Union. The values are the same as
+// the GEDCOM tags for the partners of a family. It's empty if it's unknown.
+type PartnerRole string
+
+const (
+	Husband     = PartnerRole("HUSB")
+	Wife        = PartnerRole("WIFE")
+	UnknownRole = PartnerRole("")
+)
+
+// NewUnionOf is a Union of the people, whose roles are unknown.
+func NewUnionOf(people ...*Person) *Union {
+	out := Union{Partners: make([]*Partner, len(people))}
+	for i, person := range people {
+		out.Partners[i] = &Partner{Person: person}
+	}
+	return &out
+}
+
+// People are the partners of the Union, without their roles.
+func (u *Union) People() []*Person {
+	out := make([]*Person, 0, len(u.Partners))
+	for _, partner := range u.Partners {
+		if partner != nil && partner.Person != nil {
+			out = append(out, partner.Person)
+		}
+	}
+	return out
+}
+
+// HasPartner tells if the person with the id is a partner in the Union.
+func (u *Union) HasPartner(id string) bool {
+	for _, person := range u.People() {
+		if person.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package gedcom
+
+import (
+	"context"
+
+	"github.com/funwithbots/go-gedcom/pkg/gedcom"
+	"github.com/funwithbots/go-gedcom/pkg/gedcom7"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+)
+
+// Attribute is a characteristic of an individual, such as an occupation (tag
+// OCCU) or a religion (tag RELI). It's structured like an Event, because it may
+// also have a date and a place, but it also has a value.
+type Attribute struct {
+	Event
+	// Value is the payload of the attribute line, such as "Teacher" for an
+	// occupation.
+	Value string
+}
+
+// attributeTypes are the default types for the individual attribute tags.
+var attributeTypes = map[string]string{
+	"CAST": "Caste",
+	"DSCR": "Physical description",
+	"EDUC": "Education",
+	"IDNO": "Identification number",
+	"NATI": "Nationality",
+	"NCHI": "Number of children",
+	"NMR":  "Number of marriages",
+	"OCCU": "Occupation",
+	"PROP": "Property",
+	"RELI": "Religion",
+	"SSN":  "Social security number",
+	"TITL": "Title",
+	"FACT": "Fact",
+}
+
+func parseAttribute(ctx context.Context, line *gedcom7.Line, subnodes []*gedcom.Node, opts date.ParseOptions) (out *Attribute, err error) {
+	event, err := parseEvent(ctx, line, subnodes, opts)
+	if err != nil {
+		return
+	}
+	event.setTypeIfEmpty(attributeTypes[line.Tag])
+
+	out = &Attribute{Event: *event, Value: line.Payload}
+	return
+}
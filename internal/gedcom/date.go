@@ -0,0 +1,130 @@
+package gedcom
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/funwithbots/go-gedcom/pkg/gedcom"
+	"github.com/funwithbots/go-gedcom/pkg/gedcom7"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+	"github.com/rafaelespinoza/ged/internal/log"
+)
+
+// parseDateValue interprets a DATE line, along with its PHRASE substructure.
+// Its URI is g7:DATE. If the payload cannot be interpreted as a date, then the
+// output is a phrase-only date so that the surrounding structure, such as an
+// Event, is not lost because of a textual date like "unknown".
+func parseDateValue(ctx context.Context, line *gedcom7.Line, subnodes []*gedcom.Node, opts date.ParseOptions) (dat *date.Date, rng *date.Range, err error) {
+	var phrase string
+	var subline *gedcom7.Line
+
+	for _, subnode := range subnodes {
+		if subline, err = parseLine(subnode); err != nil {
+			return
+		}
+
+		fields := map[string]any{
+			"func":    "parseDateValue",
+			"line":    line.Text,
+			"subtag":  subline.Tag,
+			"subline": subline.Text,
+		}
+
+		log.Debug(ctx, fields, "")
+
+		switch subline.Tag {
+		case "PHRASE":
+			phrase = subline.Payload
+		default:
+			log.Warn(ctx, fields, "unsupported Tag")
+		}
+	}
+
+	dat, rng, err = date.ParseWithOptions(line.Payload, opts)
+	if err != nil {
+		if phrase == "" {
+			phrase = line.Payload
+		}
+		if phrase == "" {
+			err = fmt.Errorf("empty DATE, line: %q", line.Text)
+			return
+		}
+
+		log.Warn(ctx, map[string]any{"func": "parseDateValue", "line": line.Text, "error": err.Error()}, "could not interpret DATE, keeping it as a phrase")
+		dat, err = date.NewPhrase(phrase), nil
+		dat.Payload = line.Payload
+		return
+	}
+
+	if phrase == "" {
+		// no op
+	} else if dat != nil {
+		dat.SetPhrase(phrase)
+	} else if rng != nil {
+		rng.SetPhrase(phrase)
+	}
+	return
+}
+
+// DateLine is a DATE line from a GEDCOM document, along with where it is in the
+// document. The payload is not interpreted.
+type DateLine struct {
+	// RecordXref is the Xref of the top-level record that has the DATE line.
+	// It's empty for records without an Xref, such as the HEAD.
+	RecordXref string
+	// Path is the tags of the lines from the top-level record to the DATE
+	// line, delimited by a period. For example: "INDI.BIRT.DATE".
+	Path    string
+	Payload string
+	// Phrase is the payload of the PHRASE substructure, if any.
+	Phrase string
+}
+
+// ReadDateLines collects every DATE line in the input document r.
+func ReadDateLines(ctx context.Context, r io.Reader) ([]*DateLine, error) {
+	var out []*DateLine
+	var walk func(xref string, path []string, node *gedcom.Node) error
+
+	walk = func(xref string, path []string, node *gedcom.Node) error {
+		line, err := parseLine(node)
+		if err != nil {
+			return err
+		}
+		path = append(path, line.Tag)
+
+		if line.Tag == "DATE" {
+			dateLine := DateLine{RecordXref: xref, Path: strings.Join(path, "."), Payload: line.Payload}
+			for _, subnode := range node.GetSubnodes() {
+				if subline, err := parseLine(subnode); err != nil {
+					return err
+				} else if subline.Tag == "PHRASE" {
+					dateLine.Phrase = subline.Payload
+				}
+			}
+			out = append(out, &dateLine)
+			return nil
+		}
+
+		for _, subnode := range node.GetSubnodes() {
+			if err = walk(xref, path, subnode); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i, node := range readDocument(ctx, "ReadDateLines", r) {
+		line, err := parseLine(node)
+		if err != nil {
+			return nil, fmt.Errorf("item[%d], %w", i, err)
+		}
+		if err = walk(line.Xref, nil, node); err != nil {
+			return nil, fmt.Errorf("item[%d], %w", i, err)
+		}
+	}
+
+	return out, nil
+}
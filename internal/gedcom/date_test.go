@@ -0,0 +1,71 @@
+package gedcom_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+	"github.com/rafaelespinoza/ged/internal/gedcom"
+)
+
+func TestDatePhrases(t *testing.T) {
+	data := strings.NewReader(`0 HEAD
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME Ada /Lovelace/
+1 BIRT
+2 DATE (Spring of 1850)
+2 PLAC London
+1 RESI
+2 DATE INT 1850 (about the time of the war)
+1 EVEN
+2 DATE 1850
+3 PHRASE the year of the flood
+2 TYPE Flood
+1 NATU
+2 DATE BET 1850 AND 1860
+3 PHRASE sometime in the 1850s
+1 DEAT
+2 DATE unknown
+1 BURI
+2 DATE
+3 PHRASE after the funeral
+0 TRLR
+`)
+
+	records, err := gedcom.ReadRecords(context.Background(), data, date.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records.Individuals) != 1 {
+		t.Fatalf("got %d record(s) but expected %d", len(records.Individuals), 1)
+	}
+	got := records.Individuals[0]
+
+	testEvents(t, "Birth", got.Birth, []*gedcom.Event{
+		{Type: "Birth", Date: &date.Date{Phrase: "Spring of 1850"}, Place: "London"},
+	})
+	testEvents(t, "Residences", got.Residences, []*gedcom.Event{
+		{Type: "Residence", Date: &date.Date{Year: 1850, Phrase: "about the time of the war"}},
+	})
+	testEvents(t, "Events", got.Events, []*gedcom.Event{
+		{Type: "Flood", Date: &date.Date{Year: 1850, Phrase: "the year of the flood"}},
+	})
+	testEvents(t, "Naturalizations", got.Naturalizations, []*gedcom.Event{
+		{Type: "Naturalization", DateRange: &date.Range{Lo: &date.Date{Year: 1850}, Hi: &date.Date{Year: 1860}, Phrase: "sometime in the 1850s"}},
+	})
+	testEvents(t, "Death", got.Death, []*gedcom.Event{
+		{Type: "Death", Date: &date.Date{Phrase: "unknown"}},
+	})
+	testEvents(t, "Burial", got.Burial, []*gedcom.Event{
+		{Type: "Burial", Date: &date.Date{Phrase: "after the funeral"}},
+	})
+
+	if !got.Death[0].Date.PhraseOnly() {
+		t.Errorf("expected Death date to be phrase-only")
+	}
+	if got.Death[0].Date.Display != "(unknown)" {
+		t.Errorf("wrong Display; got %q, exp %q", got.Death[0].Date.Display, "(unknown)")
+	}
+}
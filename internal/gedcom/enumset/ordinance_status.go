@@ -0,0 +1,48 @@
+package enumset
+
+// OrdinanceStatus is g7:enumset-ord-STAT. It describes the state of a Latter-day
+// Saint ordinance.
+type OrdinanceStatus string
+
+const (
+	OrdinanceBIC       = OrdinanceStatus("BIC")       // Born in the covenant, so a sealing to parents is not needed.
+	OrdinanceCanceled  = OrdinanceStatus("CANCELED")  // A sealing to a spouse was canceled.
+	OrdinanceChild     = OrdinanceStatus("CHILD")     // Died before 8 years old, so the ordinance is not needed.
+	OrdinanceCompleted = OrdinanceStatus("COMPLETED") // Completed, but the date is unknown. Deprecated as of GEDCOM7.
+	OrdinanceExcluded  = OrdinanceStatus("EXCLUDED")  // Patron excluded this ordinance from being cleared in this submission.
+	OrdinanceDNS       = OrdinanceStatus("DNS")       // Do not seal, this ordinance is not authorized.
+	OrdinanceDNSCan    = OrdinanceStatus("DNS_CAN")   // Do not seal, previous sealing canceled.
+	OrdinanceInfant    = OrdinanceStatus("INFANT")    // Died before less than 1 year old, baptism or endowment not required.
+	OrdinancePre1970   = OrdinanceStatus("PRE_1970")  // Completed before 1970, the date is not available.
+	OrdinanceStillborn = OrdinanceStatus("STILLBORN") // Stillborn, ordinances are not needed.
+	OrdinanceSubmitted = OrdinanceStatus("SUBMITTED") // Submitted, but not yet cleared.
+	OrdinanceUncleared = OrdinanceStatus("UNCLEARED") // Data for clearing this ordinance is insufficient.
+)
+
+var ordinanceStatuses = []OrdinanceStatus{
+	OrdinanceBIC,
+	OrdinanceCanceled,
+	OrdinanceChild,
+	OrdinanceCompleted,
+	OrdinanceExcluded,
+	OrdinanceDNS,
+	OrdinanceDNSCan,
+	OrdinanceInfant,
+	OrdinancePre1970,
+	OrdinanceStillborn,
+	OrdinanceSubmitted,
+	OrdinanceUncleared,
+}
+
+// NewOrdinanceStatus interprets in as an OrdinanceStatus. The output is empty
+// if the input is not a known status.
+func NewOrdinanceStatus(in string) (out OrdinanceStatus) {
+	for _, status := range ordinanceStatuses {
+		if string(status) == in {
+			out = status
+			break
+		}
+	}
+
+	return
+}
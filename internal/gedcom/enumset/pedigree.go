@@ -0,0 +1,24 @@
+package enumset
+
+import "strings"
+
+// Pedigree is g7:enumset-PEDI. It's how a child belongs to a family.
+type Pedigree string
+
+const (
+	PedigreeAdopted = Pedigree("ADOPTED")
+	PedigreeBirth   = Pedigree("BIRTH")
+	PedigreeFoster  = Pedigree("FOSTER")
+	PedigreeSealing = Pedigree("SEALING")
+	PedigreeOther   = Pedigree("OTHER")
+)
+
+// NewPedigree is empty if the input is not a known value. GEDCOM 5.5.1 values
+// are lowercase, so the comparison is case-insensitive.
+func NewPedigree(in string) (out Pedigree) {
+	switch val := Pedigree(strings.ToUpper(in)); val {
+	case PedigreeAdopted, PedigreeBirth, PedigreeFoster, PedigreeSealing, PedigreeOther:
+		out = val
+	}
+	return
+}
@@ -29,7 +29,18 @@ type Event struct {
 	Notes           []*Note
 }
 
-func parseEvent(ctx context.Context, line *gedcom7.Line, subnodes []*gedcom.Node) (out *Event, err error) {
+// dateValue is the Date or DateRange of the Event, whichever is set. It's nil
+// if neither is set.
+func (e *Event) dateValue() date.Value {
+	if e.Date != nil {
+		return e.Date
+	} else if e.DateRange != nil {
+		return e.DateRange
+	}
+	return nil
+}
+
+func parseEvent(ctx context.Context, line *gedcom7.Line, subnodes []*gedcom.Node, opts date.ParseOptions) (out *Event, err error) {
 	out = &Event{}
 
 	var subline *gedcom7.Line
@@ -55,7 +66,7 @@ func parseEvent(ctx context.Context, line *gedcom7.Line, subnodes []*gedcom.Node
 				return
 			}
 
-			out.Date, out.DateRange, err = date.Parse(subline.Payload)
+			out.Date, out.DateRange, err = parseDateValue(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				return
 			}
@@ -7,22 +7,34 @@ import (
 	"github.com/funwithbots/go-gedcom/pkg/gedcom"
 	"github.com/funwithbots/go-gedcom/pkg/gedcom7"
 
+	"github.com/rafaelespinoza/ged/internal/entity/date"
 	"github.com/rafaelespinoza/ged/internal/log"
 )
 
 // FamilyRecord is a record structure for a family. Its URI g7:record-FAM.
 type FamilyRecord struct {
-	Xref            string
-	ParentXrefs     []string
+	Xref string
+	// ParentXrefs are the Xref IDs of every partner in the family, in order.
+	ParentXrefs []string
+	// Partners are the same people as ParentXrefs, along with their roles.
+	Partners        []FamilyPartner
 	ChildXrefs      []string
 	MarriedAt       *Event
 	DivorcedAt      *Event
 	AnnulledAt      *Event
+	Ordinances      []*Ordinance
 	SourceCitations []*SourceCitation
 	Notes           []*Note
 }
 
-func parseFamilyRecord(ctx context.Context, i int, line *gedcom7.Line, subnodes []*gedcom.Node) (out *FamilyRecord, err error) {
+// FamilyPartner is a reference to a partner in a family.
+type FamilyPartner struct {
+	Xref string
+	// Role is the tag of the reference, either HUSB or WIFE.
+	Role string
+}
+
+func parseFamilyRecord(ctx context.Context, i int, line *gedcom7.Line, subnodes []*gedcom.Node, opts date.ParseOptions) (out *FamilyRecord, err error) {
 	out = &FamilyRecord{Xref: line.Xref}
 
 	var subline *gedcom7.Line
@@ -46,29 +58,37 @@ func parseFamilyRecord(ctx context.Context, i int, line *gedcom7.Line, subnodes
 		switch subline.Tag {
 		case "HUSB", "WIFE":
 			out.ParentXrefs = append(out.ParentXrefs, subline.Payload)
+			out.Partners = append(out.Partners, FamilyPartner{Xref: subline.Payload, Role: subline.Tag})
 		case "CHIL":
 			out.ChildXrefs = append(out.ChildXrefs, subline.Payload)
 		case "MARR":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing MARR, skipping")
 			} else {
 				out.MarriedAt = event
 			}
 		case "DIV":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing DIV, skipping")
 			} else {
 				out.DivorcedAt = event
 			}
 		case "ANUL":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing ANUL, skipping")
 			} else {
 				out.AnnulledAt = event
 			}
+		case "SLGS":
+			ordinance, err := parseOrdinance(ctx, subline, subnode.GetSubnodes(), opts)
+			if err != nil {
+				log.Error(ctx, fields, err, "error parsing SLGS, skipping")
+			} else {
+				out.Ordinances = append(out.Ordinances, ordinance)
+			}
 		case "SOUR":
 			citation, err := parseSourceCitation(ctx, subline, subnode.GetSubnodes())
 			if err != nil {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/rafaelespinoza/ged/internal/entity/date"
 	"github.com/rafaelespinoza/ged/internal/gedcom"
+	"github.com/rafaelespinoza/ged/internal/gedcom/enumset"
 )
 
 func TestReadRecordsSanityCheck(t *testing.T) {
@@ -37,7 +38,7 @@ func TestReadRecordsSanityCheck(t *testing.T) {
 			}
 			defer func() { _ = file.Close() }()
 
-			records, err := gedcom.ReadRecords(context.Background(), file)
+			records, err := gedcom.ReadRecords(context.Background(), file, date.ParseOptions{})
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -132,6 +133,12 @@ func TestReadRecordsFields(t *testing.T) {
 2 DATE 13 MAY 2006
 2 PLAC AOL
 2 NOTE According to the Wikipedia article on the Year 2038 Problem, AOL had a bug related to 2038-01-19.
+1 SEX M
+1 OCCU Programmer
+2 DATE 1995
+2 PLAC Silicon Valley
+1 FACT Left-handed
+2 TYPE Handedness
 1 FAMS @F1@
 0 @I2@ INDI
 1 NAME Charlene /Foxtrot/
@@ -170,6 +177,7 @@ func TestReadRecordsFields(t *testing.T) {
 2 CONT refers to potential computer errors related to the formatting and storage of calendar data for dates in and after the year 2000.
 2 LANG en
 1 FAMC @F1@
+2 PEDI adopted
 0 @F1@ FAM
 1 HUSB @I1@
 1 WIFE @I2@
@@ -207,7 +215,7 @@ func TestReadRecordsFields(t *testing.T) {
 1 REPO @R1@
 `)
 
-	records, err := gedcom.ReadRecords(context.Background(), data)
+	records, err := gedcom.ReadRecords(context.Background(), data, date.ParseOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -217,6 +225,7 @@ func TestReadRecordsFields(t *testing.T) {
 			{
 				Xref:        "@F1@",
 				ParentXrefs: []string{"@I1@", "@I2@"},
+				Partners:    []gedcom.FamilyPartner{{Xref: "@I1@", Role: "HUSB"}, {Xref: "@I2@", Role: "WIFE"}},
 				ChildXrefs:  []string{"@I3@"},
 				MarriedAt:   &gedcom.Event{Date: mustParseDate(t, "1985-01-18")},
 				DivorcedAt:  &gedcom.Event{Date: mustParseDate(t, "2000-01-01")},
@@ -237,6 +246,9 @@ func TestReadRecordsFields(t *testing.T) {
 			}
 
 			cmpStringSlices(t, errMsgPrefix+".ParentXrefs", got.ParentXrefs, exp.ParentXrefs)
+			if !slices.Equal(got.Partners, exp.Partners) {
+				t.Errorf("%swrong Partners; got %v, exp %v", errMsgPrefix+"; ", got.Partners, exp.Partners)
+			}
 			cmpStringSlices(t, errMsgPrefix+".ChildXrefs", got.ChildXrefs, exp.ChildXrefs)
 			testNotes(t, errMsgPrefix+".Notes", got.Notes, exp.Notes)
 		}
@@ -260,6 +272,17 @@ func TestReadRecordsFields(t *testing.T) {
 						Notes: []*gedcom.Note{{Payload: "According to the Wikipedia article on the Year 2038 Problem, AOL had a bug related to 2038-01-19."}},
 					},
 				},
+				Sex: enumset.Male,
+				Attributes: []*gedcom.Attribute{
+					{
+						Event: gedcom.Event{Type: "Occupation", Date: &date.Date{Year: 1995}, Place: "Silicon Valley"},
+						Value: "Programmer",
+					},
+					{
+						Event: gedcom.Event{Type: "Handedness"},
+						Value: "Left-handed",
+					},
+				},
 				FamiliesAsPartner: []string{"@F1@"},
 			},
 			{
@@ -288,6 +311,7 @@ refers to potential computer errors related to the formatting and storage of cal
 						Lang: "en"},
 				},
 				FamiliesAsChild: []string{"@F1@"},
+				ChildLinks:      []gedcom.ChildToFamilyLink{{Xref: "@F1@", Pedigree: enumset.PedigreeAdopted}},
 			},
 		}
 		if len(records.Individuals) != len(expected) {
@@ -319,8 +343,21 @@ refers to potential computer errors related to the formatting and storage of cal
 			testEvents(t, errMsgPrefix+".Death", got.Death, exp.Death)
 			testEvents(t, errMsgPrefix+".Burial", got.Burial, exp.Burial)
 			testEvents(t, errMsgPrefix+".Events", got.Events, exp.Events)
+			testAttributes(t, errMsgPrefix+".Attributes", got.Attributes, exp.Attributes)
+			if exp.Sex != "" && got.Sex != exp.Sex {
+				t.Errorf("%s; wrong Sex; got %q, exp %q", errMsgPrefix, got.Sex, exp.Sex)
+			}
 			cmpStringSlices(t, errMsgPrefix+".FamiliesAsPartner", got.FamiliesAsPartner, exp.FamiliesAsPartner)
 			cmpStringSlices(t, errMsgPrefix+".FamiliesAsChild", got.FamiliesAsChild, exp.FamiliesAsChild)
+			if len(got.ChildLinks) != len(exp.ChildLinks) {
+				t.Errorf("%s; wrong number of ChildLinks; got %d, exp %d", errMsgPrefix, len(got.ChildLinks), len(exp.ChildLinks))
+			} else {
+				for j, link := range got.ChildLinks {
+					if link != exp.ChildLinks[j] {
+						t.Errorf("%s.ChildLinks[%d]; got %+v, exp %+v", errMsgPrefix, j, link, exp.ChildLinks[j])
+					}
+				}
+			}
 			testNotes(t, errMsgPrefix+".Notes", got.Notes, exp.Notes)
 		}
 	})
@@ -493,6 +530,26 @@ func testEvents(t *testing.T, errMsgPrefix string, actual, expected []*gedcom.Ev
 	}
 }
 
+func testAttributes(t *testing.T, errMsgPrefix string, actual, expected []*gedcom.Attribute) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Errorf("%s; wrong length; got %d, exp %d", errMsgPrefix, len(actual), len(expected))
+		return
+	}
+
+	gotEvents := make([]*gedcom.Event, len(actual))
+	expEvents := make([]*gedcom.Event, len(expected))
+	for i, got := range actual {
+		exp := expected[i]
+		if got.Value != exp.Value {
+			t.Errorf("%s[%d]; wrong Value, got %q, exp %q", errMsgPrefix, i, got.Value, exp.Value)
+		}
+		gotEvents[i], expEvents[i] = &got.Event, &exp.Event
+	}
+	testEvents(t, errMsgPrefix, gotEvents, expEvents)
+}
+
 func testDate(t *testing.T, errMsgPrefix string, actual, expected *date.Date) {
 	t.Helper()
 
@@ -515,6 +572,9 @@ func testDate(t *testing.T, errMsgPrefix string, actual, expected *date.Date) {
 	if actual.Day != expected.Day {
 		t.Errorf("%s; wrong Day, got %d, exp %d", errMsgPrefix, actual.Day, expected.Day)
 	}
+	if actual.Phrase != expected.Phrase {
+		t.Errorf("%s; wrong Phrase, got %q, exp %q", errMsgPrefix, actual.Phrase, expected.Phrase)
+	}
 }
 
 func testDateRange(t *testing.T, errMsgPrefix string, actual, expected *date.Range) {
@@ -532,6 +592,9 @@ func testDateRange(t *testing.T, errMsgPrefix string, actual, expected *date.Ran
 
 	testDate(t, errMsgPrefix+".Lo", actual.Lo, expected.Lo)
 	testDate(t, errMsgPrefix+".Hi", actual.Hi, expected.Hi)
+	if actual.Phrase != expected.Phrase {
+		t.Errorf("%s; wrong Phrase, got %q, exp %q", errMsgPrefix, actual.Phrase, expected.Phrase)
+	}
 }
 
 func testNotes(t *testing.T, errMsgPrefix string, actual, expected []*gedcom.Note) {
@@ -16,18 +16,23 @@ import (
 // IndividualRecord is a record structure for an individual person. Its URI is
 // g7:record-IND.
 type IndividualRecord struct {
-	Xref              string
-	Names             []PersonalName
-	Sex               enumset.Sex
-	Birth             []*Event
-	Baptism           []*Event
-	Christening       []*Event
-	Residences        []*Event
-	Naturalizations   []*Event
-	Death             []*Event
-	Burial            []*Event
-	Events            []*Event // Other events relevant to a person. Denoted by Type field.
-	FamiliesAsChild   []string // Xref IDs of families where the person is a child.
+	Xref            string
+	Names           []PersonalName
+	Sex             enumset.Sex
+	Birth           []*Event
+	Baptism         []*Event
+	Christening     []*Event
+	Residences      []*Event
+	Naturalizations []*Event
+	Death           []*Event
+	Burial          []*Event
+	Events          []*Event // Other events relevant to a person. Denoted by Type field.
+	Attributes      []*Attribute
+	Ordinances      []*Ordinance
+	FamiliesAsChild []string // Xref IDs of families where the person is a child.
+	// ChildLinks are the same families as FamiliesAsChild, along with how the
+	// person is a child of each one.
+	ChildLinks        []ChildToFamilyLink
 	FamiliesAsPartner []string // Xref IDs of families where the person is a partner, such as a spouse.
 	SourceCitations   []*SourceCitation
 	Notes             []*Note
@@ -35,7 +40,7 @@ type IndividualRecord struct {
 	sortedEvents []*Event
 }
 
-func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subnodes []*gedcom.Node) (out *IndividualRecord, err error) {
+func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subnodes []*gedcom.Node, opts date.ParseOptions) (out *IndividualRecord, err error) {
 	out = &IndividualRecord{Xref: line.Xref}
 
 	var subline *gedcom7.Line
@@ -64,7 +69,7 @@ func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subno
 			}
 			out.Names = append(out.Names, *name)
 		case "BIRT":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
 			} else {
@@ -72,7 +77,7 @@ func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subno
 				out.Birth = append(out.Birth, event)
 			}
 		case "BAPM":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
 			} else {
@@ -80,7 +85,7 @@ func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subno
 				out.Baptism = append(out.Baptism, event)
 			}
 		case "CHR":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
 			} else {
@@ -88,7 +93,7 @@ func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subno
 				out.Christening = append(out.Christening, event)
 			}
 		case "RESI":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
 			} else {
@@ -96,7 +101,7 @@ func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subno
 				out.Residences = append(out.Residences, event)
 			}
 		case "NATU":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
 			} else {
@@ -104,7 +109,7 @@ func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subno
 				out.Naturalizations = append(out.Naturalizations, event)
 			}
 		case "EVEN":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
 			} else {
@@ -112,7 +117,7 @@ func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subno
 				out.Events = append(out.Events, event)
 			}
 		case "DEAT":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
 			} else {
@@ -120,17 +125,32 @@ func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subno
 				out.Death = append(out.Death, event)
 			}
 		case "BURI":
-			event, err := parseEvent(ctx, subline, subnode.GetSubnodes())
+			event, err := parseEvent(ctx, subline, subnode.GetSubnodes(), opts)
 			if err != nil {
 				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
 			} else {
 				event.setTypeIfEmpty("Burial")
 				out.Burial = append(out.Burial, event)
 			}
+		case "CAST", "DSCR", "EDUC", "IDNO", "NATI", "NCHI", "NMR", "OCCU", "PROP", "RELI", "SSN", "TITL", "FACT":
+			attribute, err := parseAttribute(ctx, subline, subnode.GetSubnodes(), opts)
+			if err != nil {
+				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
+			} else {
+				out.Attributes = append(out.Attributes, attribute)
+			}
+		case "BAPL", "CONL", "ENDL", "INIL", "SLGC":
+			ordinance, err := parseOrdinance(ctx, subline, subnode.GetSubnodes(), opts)
+			if err != nil {
+				log.Error(ctx, fields, err, "error parsing "+tag+", skipping")
+			} else {
+				out.Ordinances = append(out.Ordinances, ordinance)
+			}
 		case "SEX":
 			out.Sex = enumset.NewSex(subline.Payload)
 		case "FAMC":
 			out.FamiliesAsChild = append(out.FamiliesAsChild, subline.Payload)
+			out.ChildLinks = append(out.ChildLinks, parseChildToFamilyLink(ctx, subline, subnode.GetSubnodes()))
 		case "FAMS":
 			out.FamiliesAsPartner = append(out.FamiliesAsPartner, subline.Payload)
 		case "SOUR":
@@ -153,6 +173,37 @@ func parseIndividualRecord(ctx context.Context, i int, line *gedcom7.Line, subno
 	return
 }
 
+// ChildToFamilyLink is a reference to a family where a person is a child. Its
+// URI is g7:CHIL-FAMC.
+type ChildToFamilyLink struct {
+	Xref string
+	// Pedigree is empty if it's unknown, which usually means a birth.
+	Pedigree enumset.Pedigree
+}
+
+func parseChildToFamilyLink(ctx context.Context, line *gedcom7.Line, subnodes []*gedcom.Node) (out ChildToFamilyLink) {
+	out.Xref = line.Payload
+
+	for _, subnode := range subnodes {
+		subline, err := parseLine(subnode)
+		if err != nil {
+			log.Error(ctx, map[string]any{"line": line.Text}, err, "error parsing FAMC subline, skipping")
+			continue
+		}
+		if subline.Tag != "PEDI" {
+			continue
+		}
+		if out.Pedigree = enumset.NewPedigree(subline.Payload); out.Pedigree == "" {
+			log.Warn(ctx, map[string]any{"line": line.Text, "pedigree": subline.Payload}, "unknown PEDI value")
+		}
+	}
+
+	return
+}
+
+// EventLog collects all of the events of the individual, in chronological
+// order. Events whose dates could be in either order are sorted by the earliest
+// possible day. Events without dates are last.
 func (i *IndividualRecord) EventLog() []*Event {
 	if i.sortedEvents != nil {
 		return i.sortedEvents
@@ -181,18 +232,14 @@ func (i *IndividualRecord) EventLog() []*Event {
 	}
 
 	slices.SortStableFunc(out, func(left, right *Event) int {
-		if left.Date != nil && right.Date != nil {
-			return date.CmpDates(left.Date, right.Date)
+		l, r := left.dateValue(), right.dateValue()
+		if l != nil && r != nil {
+			return date.CmpValues(l, r)
 		}
-		if left.DateRange != nil && right.DateRange != nil {
-			return date.CmpDateRanges(left.DateRange, right.DateRange)
-		}
-
-		if left.Date != nil && right.DateRange != nil {
-			return date.CmpDateToDateRange(left.Date, right.DateRange)
-		} else if left.Date != nil && right.DateRange == nil {
+		// Events without any date go last.
+		if l != nil {
 			return -1
-		} else if left.Date == nil && right.DateRange != nil {
+		} else if r != nil {
 			return 1
 		}
 		return 0
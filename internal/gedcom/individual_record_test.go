@@ -2,6 +2,7 @@ package gedcom_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/rafaelespinoza/ged/internal/entity/date"
 	"github.com/rafaelespinoza/ged/internal/gedcom"
@@ -35,4 +36,55 @@ func TestIndividualRecordEventLog(t *testing.T) {
 	if len(results) != 10 {
 		t.Fatalf("wrong number of results, got %d, exp %d", len(results), 10)
 	}
+
+	expected := []*gedcom.Event{
+		indRecord.Birth[0],
+		indRecord.Residences[0],
+		indRecord.Events[0],
+		indRecord.Naturalizations[0],
+		indRecord.Naturalizations[1],
+		indRecord.Events[1],
+		indRecord.Residences[1],
+		indRecord.Events[2],
+		indRecord.Events[3],
+		indRecord.Death[0],
+	}
+	for i, exp := range expected {
+		if results[i] != exp {
+			t.Errorf("item[%d] wrong event; got %+v, expected %+v", i, results[i], exp)
+		}
+	}
+}
+
+func TestIndividualRecordEventLogUncertainDates(t *testing.T) {
+	indRecord := gedcom.IndividualRecord{
+		Birth: []*gedcom.Event{
+			{Date: &date.Date{Year: 1850, Approximate: true}, Type: "Birth"},
+		},
+		Baptism: []*gedcom.Event{
+			{Date: mustParseDate(t, "1850-03-03"), Type: "Baptism"},
+		},
+		Residences: []*gedcom.Event{
+			{DateRange: &date.Range{Hi: &date.Date{Year: 1849}}, Type: "Residence"},
+			{Type: "Residence"},
+			{DateRange: &date.Range{Lo: &date.Date{Year: 1850, Month: time.June}}, Type: "Residence"},
+		},
+	}
+
+	results := indRecord.EventLog()
+	expected := []*gedcom.Event{
+		indRecord.Residences[0],
+		indRecord.Birth[0],
+		indRecord.Baptism[0],
+		indRecord.Residences[2],
+		indRecord.Residences[1],
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("wrong number of results, got %d, exp %d", len(results), len(expected))
+	}
+	for i, exp := range expected {
+		if results[i] != exp {
+			t.Errorf("item[%d] wrong event; got %+v, expected %+v", i, results[i], exp)
+		}
+	}
 }
@@ -0,0 +1,153 @@
+package gedcom
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/funwithbots/go-gedcom/pkg/gedcom"
+	"github.com/funwithbots/go-gedcom/pkg/gedcom7"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+	"github.com/rafaelespinoza/ged/internal/gedcom/enumset"
+	"github.com/rafaelespinoza/ged/internal/log"
+)
+
+// Ordinance is a religious ordinance of The Church of Jesus Christ of
+// Latter-day Saints. It's similar to an Event, but has its own set of
+// substructures. The GEDCOM7 spec calls these LDS_INDIVIDUAL_ORDINANCE for the
+// tags BAPL, CONL, ENDL, INIL, SLGC; and LDS_SPOUSE_SEALING for the tag SLGS.
+type Ordinance struct {
+	// Tag is the original GEDCOM tag, such as BAPL or SLGS.
+	Tag string
+	// Type is a human-friendly description of the Tag.
+	Type      string
+	Date      *date.Date
+	DateRange *date.Range
+	// Temple is the abbreviation of the temple where the ordinance was
+	// performed. Its URI is g7:TEMP.
+	Temple string
+	Place  string
+	Status *OrdinanceStatus
+	// FamilyXref is only applicable to a sealing of a child to parents (tag
+	// SLGC). It's the Xref of the family to which the child was sealed.
+	FamilyXref      string
+	SourceCitations []*SourceCitation
+	Notes           []*Note
+}
+
+// OrdinanceStatus describes the state of an Ordinance, and when it was
+// reached. Its URI is g7:ord-STAT.
+type OrdinanceStatus struct {
+	Status enumset.OrdinanceStatus
+	Date   *date.Date
+}
+
+// ordinanceTypes maps an ordinance tag to a description.
+var ordinanceTypes = map[string]string{
+	"BAPL": "Baptism (LDS)",
+	"CONL": "Confirmation (LDS)",
+	"ENDL": "Endowment (LDS)",
+	"INIL": "Initiatory (LDS)",
+	"SLGC": "Sealing to parents (LDS)",
+	"SLGS": "Sealing to spouse (LDS)",
+}
+
+func parseOrdinance(ctx context.Context, line *gedcom7.Line, subnodes []*gedcom.Node, opts date.ParseOptions) (out *Ordinance, err error) {
+	out = &Ordinance{Tag: line.Tag, Type: ordinanceTypes[line.Tag]}
+
+	var subline *gedcom7.Line
+
+	for _, subnode := range subnodes {
+		if subline, err = parseLine(subnode); err != nil {
+			return
+		}
+
+		fields := map[string]any{
+			"func":    "parseOrdinance",
+			"line":    line.Text,
+			"subtag":  subline.Tag,
+			"subline": subline.Text,
+		}
+
+		log.Debug(ctx, fields, "")
+
+		switch subline.Tag {
+		case "DATE":
+			if out.Date != nil || out.DateRange != nil {
+				err = fmt.Errorf("error parsing ordinance, multiple DATE lines, conflicting line: %q", subline.Text)
+				return
+			}
+
+			out.Date, out.DateRange, err = parseDateValue(ctx, subline, subnode.GetSubnodes(), opts)
+			if err != nil {
+				return
+			}
+		case "TEMP":
+			out.Temple = subline.Payload
+		case "PLAC":
+			if out.Place != "" {
+				err = fmt.Errorf("error parsing ordinance, multiple PLAC lines, conflicting line: %q", subline.Text)
+				return
+			}
+
+			out.Place = subline.Payload
+		case "STAT":
+			if out.Status, err = parseOrdinanceStatus(ctx, subline, subnode.GetSubnodes(), opts); err != nil {
+				return
+			}
+		case "FAMC":
+			out.FamilyXref = subline.Payload
+		case "SOUR":
+			citation, err := parseSourceCitation(ctx, subline, subnode.GetSubnodes())
+			if err != nil {
+				return nil, fmt.Errorf("error parsing source citation: %w", err)
+			}
+			out.SourceCitations = append(out.SourceCitations, citation)
+		case "NOTE":
+			note, err := parseNote(ctx, subline, subnode.GetSubnodes())
+			if err != nil {
+				return nil, fmt.Errorf("error parsing note: %w", err)
+			}
+			out.Notes = append(out.Notes, note)
+		default:
+			log.Warn(ctx, fields, "unsupported Tag")
+		}
+	}
+
+	return
+}
+
+func parseOrdinanceStatus(ctx context.Context, line *gedcom7.Line, subnodes []*gedcom.Node, opts date.ParseOptions) (out *OrdinanceStatus, err error) {
+	out = &OrdinanceStatus{Status: enumset.NewOrdinanceStatus(line.Payload)}
+	if out.Status == "" {
+		log.Warn(ctx, map[string]any{"func": "parseOrdinanceStatus", "line": line.Text}, "unknown ordinance status")
+	}
+
+	var subline *gedcom7.Line
+
+	for _, subnode := range subnodes {
+		if subline, err = parseLine(subnode); err != nil {
+			return
+		}
+
+		switch subline.Tag {
+		case "DATE":
+			var rng *date.Range
+			if out.Date, rng, err = date.ParseWithOptions(subline.Payload, opts); err != nil {
+				return
+			} else if rng != nil {
+				err = fmt.Errorf("error parsing ordinance status, DATE should be exact, line: %q", subline.Text)
+				return
+			}
+		default:
+			log.Warn(ctx, map[string]any{
+				"func":    "parseOrdinanceStatus",
+				"line":    line.Text,
+				"subtag":  subline.Tag,
+				"subline": subline.Text,
+			}, "unsupported Tag")
+		}
+	}
+
+	return
+}
@@ -0,0 +1,183 @@
+package gedcom_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+	"github.com/rafaelespinoza/ged/internal/gedcom"
+	"github.com/rafaelespinoza/ged/internal/gedcom/enumset"
+)
+
+func TestOrdinances(t *testing.T) {
+	data := strings.NewReader(`0 HEAD
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME Joseph /Smith/
+1 BAPL
+2 DATE 15 MAY 1829
+2 PLAC Susquehanna River, Pennsylvania
+2 STAT COMPLETED
+3 DATE 16 MAY 1829
+1 CONL
+2 DATE 1830
+2 TEMP SLAKE
+1 ENDL
+2 STAT SUBMITTED
+3 DATE 1 JAN 2000
+2 NOTE The status date is when the status was reached.
+1 SLGC
+2 FAMC @F1@
+2 STAT BIC
+3 DATE 2 JAN 2000
+1 FAMS @F2@
+0 @I2@ INDI
+1 NAME Emma /Hale/
+1 INIL
+2 DATE BET 1840 AND 1845
+1 FAMS @F2@
+0 @F1@ FAM
+1 CHIL @I1@
+0 @F2@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 MARR
+2 DATE 18 JAN 1827
+1 SLGS
+2 DATE 28 MAY 1843
+2 TEMP NAUVO
+2 SOUR @S1@
+3 PAGE p. 1
+0 TRLR
+`)
+
+	records, err := gedcom.ReadRecords(context.Background(), data, date.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Individuals", func(t *testing.T) {
+		expected := map[string][]*gedcom.Ordinance{
+			"@I1@": {
+				{
+					Tag:    "BAPL",
+					Type:   "Baptism (LDS)",
+					Date:   mustParseDate(t, "1829-05-15"),
+					Place:  "Susquehanna River, Pennsylvania",
+					Status: &gedcom.OrdinanceStatus{Status: enumset.OrdinanceCompleted, Date: mustParseDate(t, "1829-05-16")},
+				},
+				{
+					Tag:    "CONL",
+					Type:   "Confirmation (LDS)",
+					Date:   &date.Date{Year: 1830},
+					Temple: "SLAKE",
+				},
+				{
+					Tag:    "ENDL",
+					Type:   "Endowment (LDS)",
+					Status: &gedcom.OrdinanceStatus{Status: enumset.OrdinanceSubmitted, Date: mustParseDate(t, "2000-01-01")},
+					Notes:  []*gedcom.Note{{Payload: "The status date is when the status was reached."}},
+				},
+				{
+					Tag:        "SLGC",
+					Type:       "Sealing to parents (LDS)",
+					FamilyXref: "@F1@",
+					Status:     &gedcom.OrdinanceStatus{Status: enumset.OrdinanceBIC, Date: mustParseDate(t, "2000-01-02")},
+				},
+			},
+			"@I2@": {
+				{
+					Tag:       "INIL",
+					Type:      "Initiatory (LDS)",
+					DateRange: &date.Range{Lo: &date.Date{Year: 1840}, Hi: &date.Date{Year: 1845}},
+				},
+			},
+		}
+
+		if len(records.Individuals) != len(expected) {
+			t.Fatalf("got %d record(s) but expected %d", len(records.Individuals), len(expected))
+		}
+
+		for _, got := range records.Individuals {
+			testOrdinances(t, got.Xref, got.Ordinances, expected[got.Xref])
+		}
+	})
+
+	t.Run("Families", func(t *testing.T) {
+		expected := map[string][]*gedcom.Ordinance{
+			"@F1@": nil,
+			"@F2@": {
+				{
+					Tag:             "SLGS",
+					Type:            "Sealing to spouse (LDS)",
+					Date:            mustParseDate(t, "1843-05-28"),
+					Temple:          "NAUVO",
+					SourceCitations: []*gedcom.SourceCitation{{Xref: "@S1@", Page: "p. 1"}},
+				},
+			},
+		}
+
+		if len(records.Families) != len(expected) {
+			t.Fatalf("got %d record(s) but expected %d", len(records.Families), len(expected))
+		}
+
+		for _, got := range records.Families {
+			testOrdinances(t, got.Xref, got.Ordinances, expected[got.Xref])
+		}
+	})
+}
+
+func testOrdinances(t *testing.T, errMsgPrefix string, actual, expected []*gedcom.Ordinance) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Errorf("%s; wrong length; got %d, exp %d", errMsgPrefix, len(actual), len(expected))
+		return
+	}
+
+	for i, got := range actual {
+		errMsgPrefix := fmt.Sprintf("%s[%d]", errMsgPrefix, i)
+		exp := expected[i]
+
+		if got.Tag != exp.Tag {
+			t.Errorf("%s; wrong Tag, got %q, exp %q", errMsgPrefix, got.Tag, exp.Tag)
+		}
+		if got.Type != exp.Type {
+			t.Errorf("%s; wrong Type, got %q, exp %q", errMsgPrefix, got.Type, exp.Type)
+		}
+		testDate(t, errMsgPrefix+".Date", got.Date, exp.Date)
+		testDateRange(t, errMsgPrefix+".DateRange", got.DateRange, exp.DateRange)
+		if got.Temple != exp.Temple {
+			t.Errorf("%s; wrong Temple, got %q, exp %q", errMsgPrefix, got.Temple, exp.Temple)
+		}
+		if got.Place != exp.Place {
+			t.Errorf("%s; wrong Place, got %q, exp %q", errMsgPrefix, got.Place, exp.Place)
+		}
+		if got.FamilyXref != exp.FamilyXref {
+			t.Errorf("%s; wrong FamilyXref, got %q, exp %q", errMsgPrefix, got.FamilyXref, exp.FamilyXref)
+		}
+
+		if got.Status != nil && exp.Status == nil {
+			t.Errorf("%s; expected empty Status, but got %v", errMsgPrefix, got.Status)
+		} else if got.Status == nil && exp.Status != nil {
+			t.Errorf("%s; expected non-empty Status, but got %v", errMsgPrefix, got.Status)
+		} else if got.Status != nil && exp.Status != nil {
+			if got.Status.Status != exp.Status.Status {
+				t.Errorf("%s; wrong Status.Status, got %q, exp %q", errMsgPrefix, got.Status.Status, exp.Status.Status)
+			}
+			testDate(t, errMsgPrefix+".Status.Date", got.Status.Date, exp.Status.Date)
+		}
+
+		if len(got.SourceCitations) != len(exp.SourceCitations) {
+			t.Errorf("%s; wrong number of SourceCitations; got %d, exp %d", errMsgPrefix, len(got.SourceCitations), len(exp.SourceCitations))
+		} else {
+			for j, got := range got.SourceCitations {
+				errMsgPrefix := fmt.Sprintf("%s[%d], ", errMsgPrefix, j)
+				cmpSourceCitation(t, errMsgPrefix, got, exp.SourceCitations[j])
+			}
+		}
+		testNotes(t, errMsgPrefix+".Notes", got.Notes, exp.Notes)
+	}
+}
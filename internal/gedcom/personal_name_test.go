@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/rafaelespinoza/ged/internal/entity/date"
 	"github.com/rafaelespinoza/ged/internal/gedcom"
 )
 
@@ -17,7 +18,7 @@ func TestPersonalName(t *testing.T) {
 	}
 
 	runTest := func(t *testing.T, test Testcase) {
-		records, err := gedcom.ReadRecords(context.Background(), strings.NewReader(test.RawData))
+		records, err := gedcom.ReadRecords(context.Background(), strings.NewReader(test.RawData), date.ParseOptions{})
 		if err != nil {
 			t.Fatal(err)
 		}
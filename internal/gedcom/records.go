@@ -9,6 +9,7 @@ import (
 	"github.com/funwithbots/go-gedcom/pkg/gedcom"
 	"github.com/funwithbots/go-gedcom/pkg/gedcom7"
 
+	"github.com/rafaelespinoza/ged/internal/entity/date"
 	"github.com/rafaelespinoza/ged/internal/log"
 )
 
@@ -19,21 +20,10 @@ type Records struct {
 	Sources     []*SourceRecord
 }
 
-// ReadRecords reads constructs Records out of the input document r.
-func ReadRecords(ctx context.Context, r io.Reader) (*Records, error) {
-	doc := gedcom7.NewDocument(bufio.NewScanner(r), gedcom7.WithMaxDeprecatedTags("5.5.1"))
-
-	warnings := doc.GetWarnings()
-	fields := map[string]any{
-		"func":         "ReadRecords",
-		"num_records":  doc.Len(),
-		"num_warnings": len(warnings),
-		"warnings":     warnings,
-	}
-
-	log.Info(ctx, fields, "processed gedcom7 document")
-
-	nodes := doc.Records()
+// ReadRecords reads constructs Records out of the input document r. The DATE
+// payloads are interpreted with opts.
+func ReadRecords(ctx context.Context, r io.Reader, opts date.ParseOptions) (*Records, error) {
+	nodes := readDocument(ctx, "ReadRecords", r)
 	out := Records{
 		Individuals: make([]*IndividualRecord, 0, len(nodes)),
 		Families:    make([]*FamilyRecord, 0, len(nodes)),
@@ -47,13 +37,13 @@ func ReadRecords(ctx context.Context, r io.Reader) (*Records, error) {
 
 		switch line.Tag {
 		case "INDI":
-			individual, err := parseIndividualRecord(ctx, i, line, node.GetSubnodes())
+			individual, err := parseIndividualRecord(ctx, i, line, node.GetSubnodes(), opts)
 			if err != nil {
 				return nil, fmt.Errorf("error parsing individual record, line=%q: %w", line.String(), err)
 			}
 			out.Individuals = append(out.Individuals, individual)
 		case "FAM":
-			family, err := parseFamilyRecord(ctx, i, line, node.GetSubnodes())
+			family, err := parseFamilyRecord(ctx, i, line, node.GetSubnodes(), opts)
 			if err != nil {
 				return nil, fmt.Errorf("error parsing family record, line=%q: %w", line.String(), err)
 			}
@@ -78,6 +68,24 @@ func ReadRecords(ctx context.Context, r io.Reader) (*Records, error) {
 	return &out, nil
 }
 
+// readDocument processes the input document r and returns its top-level
+// records. The caller is the name of the calling function, for logging.
+func readDocument(ctx context.Context, caller string, r io.Reader) []*gedcom.Node {
+	doc := gedcom7.NewDocument(bufio.NewScanner(r), gedcom7.WithMaxDeprecatedTags("5.5.1"))
+
+	warnings := doc.GetWarnings()
+	fields := map[string]any{
+		"func":         caller,
+		"num_records":  doc.Len(),
+		"num_warnings": len(warnings),
+		"warnings":     warnings,
+	}
+
+	log.Info(ctx, fields, "processed gedcom7 document")
+
+	return doc.Records()
+}
+
 func parseLine(node *gedcom.Node) (line *gedcom7.Line, err error) {
 	switch val := node.GetValue().(type) {
 	case *gedcom7.Line:
@@ -0,0 +1,120 @@
+package srv
+
+import "math"
+
+// CoefficientOfRelationship is Wright's coefficient of relationship between
+// the people at id1 and id2. It's the expected fraction of DNA that they share
+// by descent, such as 0.5 for siblings or 0.125 for 1st cousins. Each path
+// through a common ancestor counts, so double 1st cousins have 0.25. Only
+// parents and children related by birth are considered. It's 0 if either
+// person is not found, or if they are not related by blood.
+func (g *Graph) CoefficientOfRelationship(id1, id2 string) float64 {
+	return newCoefficients(g).relationship(id1, id2)
+}
+
+// InbreedingCoefficient is the probability that both copies of a gene of the
+// person at id are identical by descent. It's the same as the kinship
+// coefficient of their birth parents, so it's 0 unless both birth parents are
+// known and are related to each other.
+func (g *Graph) InbreedingCoefficient(id string) float64 {
+	return newCoefficients(g).inbreeding(id)
+}
+
+// InbreedingCoefficients are the inbreeding coefficients of the people in the
+// Graph, keyed by person ID. Only the non-zero values are in there.
+func (g *Graph) InbreedingCoefficients() map[string]float64 {
+	calc := newCoefficients(g)
+	out := make(map[string]float64)
+	for _, person := range g.people {
+		if f := calc.inbreeding(person.ID); f > 0 {
+			out[person.ID] = f
+		}
+	}
+	return out
+}
+
+// coefficients calculates genetic coefficients of the people in a Graph. The
+// intermediate results are saved because the same ancestors come up often.
+type coefficients struct {
+	r              *relator
+	linesByID      map[string]map[string][][]string
+	inbreedingByID map[string]float64
+}
+
+func newCoefficients(g *Graph) *coefficients {
+	return &coefficients{
+		r:              &relator{graph: g, genetic: true},
+		linesByID:      make(map[string]map[string][][]string),
+		inbreedingByID: make(map[string]float64),
+	}
+}
+
+// relationship is the kinship coefficient, doubled, and then adjusted for the
+// inbreeding of each person.
+func (c *coefficients) relationship(id1, id2 string) float64 {
+	kinship := c.kinship(id1, id2)
+	if kinship == 0 {
+		return 0
+	}
+	return 2 * kinship / math.Sqrt((1+c.inbreeding(id1))*(1+c.inbreeding(id2)))
+}
+
+// kinship is the probability that a gene picked at random from each person is
+// identical by descent. Each pair of ancestral lines that only meet at a common
+// ancestor adds (1/2)^(n+1) * (1 + F), where n is the number of parent-child
+// links on both lines, and F is the inbreeding coefficient of the ancestor.
+func (c *coefficients) kinship(id1, id2 string) (out float64) {
+	if _, ok := c.r.lookupOne(id1); !ok {
+		return
+	}
+	if _, ok := c.r.lookupOne(id2); !ok {
+		return
+	}
+
+	lines1, lines2 := c.ancestralLines(id1), c.ancestralLines(id2)
+	for ancestorID, ancestorLines1 := range lines1 {
+		ancestorLines2, ok := lines2[ancestorID]
+		if !ok {
+			continue
+		}
+
+		var sum float64
+		for _, line1 := range ancestorLines1 {
+			for _, line2 := range ancestorLines2 {
+				if meetOnlyAtEnd(line1, line2) {
+					sum += math.Pow(0.5, float64(len(line1)+len(line2)-1))
+				}
+			}
+		}
+		if sum > 0 {
+			out += sum * (1 + c.inbreeding(ancestorID))
+		}
+	}
+	return
+}
+
+// inbreeding is the kinship coefficient of the birth parents of the person at
+// id.
+func (c *coefficients) inbreeding(id string) float64 {
+	if f, ok := c.inbreedingByID[id]; ok {
+		return f
+	}
+	// Guard against cycles in the data while this is calculated.
+	c.inbreedingByID[id] = 0
+
+	var f float64
+	if parentIDs := c.r.parentIDs(id); len(parentIDs) == 2 {
+		f = c.kinship(parentIDs[0], parentIDs[1])
+	}
+	c.inbreedingByID[id] = f
+	return f
+}
+
+func (c *coefficients) ancestralLines(id string) map[string][][]string {
+	lines, ok := c.linesByID[id]
+	if !ok {
+		lines = c.r.ancestralLines(id)
+		c.linesByID[id] = lines
+	}
+	return lines
+}
@@ -0,0 +1,101 @@
+package srv
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+func TestCoefficients(t *testing.T) {
+	// Siblings @I3@ and @I4@ marry siblings @I7@ and @I8@, so their children
+	// @I9@ and @I10@ are double 1st cousins. The father @I3@ also has a child
+	// @I11@ with @I12@. Then the double 1st cousins @I9@ and @I13@ have a child
+	// @I14@, and the siblings @I10@ and @I13@ have a child @I15@. The adopted
+	// child @I16@ of @I9@ and @I13@ is not inbred.
+	grandparents1 := []*entity.Person{{ID: "@I1@"}, {ID: "@I2@"}}
+	grandparents2 := []*entity.Person{{ID: "@I5@"}, {ID: "@I6@"}}
+	parentA := &entity.Person{ID: "@I3@", Parents: grandparents1}
+	parentB := &entity.Person{ID: "@I4@", Parents: grandparents1}
+	parentC := &entity.Person{ID: "@I7@", Parents: grandparents2}
+	parentD := &entity.Person{ID: "@I8@", Parents: grandparents2}
+	otherParent := &entity.Person{ID: "@I12@"}
+	cousinX := &entity.Person{ID: "@I9@", Parents: []*entity.Person{parentA, parentC}}
+	cousinY := &entity.Person{ID: "@I10@", Parents: []*entity.Person{parentB, parentD}}
+	halfSibling := &entity.Person{ID: "@I11@", Parents: []*entity.Person{parentA, otherParent}}
+	cousinZ := &entity.Person{ID: "@I13@", Parents: []*entity.Person{parentB, parentD}}
+	cousinsChild := &entity.Person{ID: "@I14@", Parents: []*entity.Person{cousinX, cousinZ}}
+	siblingsChild := &entity.Person{ID: "@I15@", Parents: []*entity.Person{cousinY, cousinZ}}
+	adoptedChild := &entity.Person{
+		ID:              "@I16@",
+		Parents:         []*entity.Person{cousinX, cousinZ},
+		ParentPedigrees: map[string]entity.Pedigree{cousinX.ID: entity.Adopted, cousinZ.ID: entity.Adopted},
+	}
+
+	people := append(append([]*entity.Person{}, grandparents1...), grandparents2...)
+	people = append(people, parentA, parentB, parentC, parentD, otherParent, cousinX, cousinY, halfSibling, cousinZ, cousinsChild, siblingsChild, adoptedChild)
+	graph := NewGraph(people, nil)
+
+	t.Run("CoefficientOfRelationship", func(t *testing.T) {
+		tests := []struct {
+			Name       string
+			InP1, InP2 string
+			Exp        float64
+		}{
+			{Name: "self", InP1: cousinX.ID, InP2: cousinX.ID, Exp: 1},
+			{Name: "parent", InP1: cousinX.ID, InP2: parentA.ID, Exp: 0.5},
+			{Name: "sibling", InP1: parentA.ID, InP2: parentB.ID, Exp: 0.5},
+			{Name: "half sibling", InP1: cousinX.ID, InP2: halfSibling.ID, Exp: 0.25},
+			{Name: "grandparent", InP1: cousinX.ID, InP2: "@I1@", Exp: 0.25},
+			{Name: "aunt/uncle", InP1: cousinX.ID, InP2: parentB.ID, Exp: 0.25},
+			{Name: "double 1st cousins", InP1: cousinX.ID, InP2: cousinY.ID, Exp: 0.25},
+			{Name: "spouses", InP1: parentA.ID, InP2: parentC.ID, Exp: 0},
+			{Name: "not found", InP1: parentA.ID, InP2: "@I999@", Exp: 0},
+			{Name: "adopted", InP1: adoptedChild.ID, InP2: cousinX.ID, Exp: 0},
+		}
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				got := graph.CoefficientOfRelationship(test.InP1, test.InP2)
+				if math.Abs(got-test.Exp) > 1e-9 {
+					t.Errorf("wrong output; got %v, expected %v", got, test.Exp)
+				}
+			})
+		}
+	})
+
+	t.Run("InbreedingCoefficient", func(t *testing.T) {
+		tests := []struct {
+			Name string
+			InID string
+			Exp  float64
+		}{
+			{Name: "child of double 1st cousins", InID: cousinsChild.ID, Exp: 0.125},
+			{Name: "child of siblings", InID: siblingsChild.ID, Exp: 0.25},
+			{Name: "adopted", InID: adoptedChild.ID, Exp: 0},
+			{Name: "unrelated parents", InID: cousinX.ID, Exp: 0},
+			{Name: "unknown parents", InID: parentA.ID, Exp: 0},
+		}
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				got := graph.InbreedingCoefficient(test.InID)
+				if math.Abs(got-test.Exp) > 1e-9 {
+					t.Errorf("wrong output; got %v, expected %v", got, test.Exp)
+				}
+			})
+		}
+	})
+
+	t.Run("InbreedingCoefficients", func(t *testing.T) {
+		got := graph.InbreedingCoefficients()
+		if len(got) != 2 {
+			t.Errorf("wrong number of results; got %d, expected %d", len(got), 2)
+		}
+		for _, id := range []string{cousinsChild.ID, siblingsChild.ID} {
+			if got[id] != graph.InbreedingCoefficient(id) {
+				t.Errorf("wrong value for %q; got %v, expected %v", id, got[id], graph.InbreedingCoefficient(id))
+			}
+		}
+	})
+}
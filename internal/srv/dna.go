@@ -0,0 +1,97 @@
+package srv
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+// DNALine is a direct paternal or maternal line of a person, and the people
+// who would carry the same Y chromosome or mitochondrial DNA (mtDNA) as
+// everyone on that line.
+type DNALine struct {
+	// Line starts with the person, then their father or mother, then their
+	// father's father or mother's mother, and so on. The last one is the
+	// earliest known ancestor on the line.
+	Line []*entity.Person
+	// Carriers are the descendants of the earliest known ancestor on the line
+	// who inherited the DNA, and whose LivingStatus is living. They're in the
+	// order of a breadth-first traversal from that ancestor.
+	Carriers []*entity.Person
+}
+
+// PaternalLine is the direct paternal line of the person at id, and the living
+// carriers of its Y chromosome. A father passes his Y chromosome to his sons,
+// so each carrier is a male who descends from the earliest known ancestor
+// only through males. Only birth parents and children are considered.
+func (g *Graph) PaternalLine(id string) (out DNALine, err error) {
+	return g.dnaLine(id, entity.Male)
+}
+
+// MaternalLine is the direct maternal line of the person at id, and the living
+// carriers of its mtDNA. A mother passes her mtDNA to all of her children, but
+// only a daughter passes it on. So each carrier descends from the earliest
+// known ancestor only through females. Only birth parents and children are
+// considered.
+func (g *Graph) MaternalLine(id string) (out DNALine, err error) {
+	return g.dnaLine(id, entity.Female)
+}
+
+// dnaLine follows the parents of the given sex from the person at id. Then it
+// goes back down to the descendants that inherit the DNA of that line.
+func (g *Graph) dnaLine(id string, sex entity.Sex) (out DNALine, err error) {
+	person, ok := g.Person(id)
+	if !ok {
+		err = fmt.Errorf("person with id %v not found", id)
+		return
+	}
+	r := &relator{graph: g, genetic: true}
+
+	out.Line = []*entity.Person{person}
+	for len(out.Line) <= maxGenerationsToRelate {
+		parentIDs := r.parentIDs(out.Line[len(out.Line)-1].ID)
+		i := slices.IndexFunc(parentIDs, func(parentID string) bool { return r.sexOf(parentID) == sex })
+		if i < 0 {
+			break
+		}
+		parent, ok := g.Person(parentIDs[i])
+		if !ok || slices.Contains(out.Line, parent) {
+			break // unknown, or the data has a cycle.
+		}
+		out.Line = append(out.Line, parent)
+	}
+
+	// Males carry the Y chromosome, and only males pass it on. Anyone may
+	// carry mtDNA, but only females pass it on.
+	carries := func(p *entity.Person) bool { return sex == entity.Female || p.Sex == entity.Male }
+	passes := func(p *entity.Person) bool { return p.Sex == sex }
+
+	visited := make(idSet)
+	queue := []*entity.Person{out.Line[len(out.Line)-1]}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		if visited.has(curr.ID) {
+			continue
+		}
+		visited.add(curr.ID)
+
+		if carries(curr) && curr.LivingStatus == entity.Living {
+			out.Carriers = append(out.Carriers, curr)
+		}
+		if !passes(curr) {
+			continue
+		}
+		for _, childID := range g.childIDs[curr.ID] {
+			if !slices.Contains(r.parentIDs(childID), curr.ID) {
+				continue // not a birth child.
+			}
+			if child, ok := g.Person(childID); ok {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	return
+}
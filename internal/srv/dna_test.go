@@ -0,0 +1,114 @@
+package srv
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+func TestDNA(t *testing.T) {
+	// The grandparents @I1@ and @I2@ have a son @I3@ and a daughter @I4@. The
+	// son and @I6@ have a son @I5@ and a daughter @I7@. The daughter and @I9@
+	// have a son @I8@ and a daughter @I10@. The son @I11@ of @I3@ is adopted.
+	// The grandfather is deceased, and whether the grandmother is living is
+	// unknown.
+	grandpa := &entity.Person{ID: "@I1@", Sex: entity.Male, LivingStatus: entity.Deceased}
+	grandma := &entity.Person{ID: "@I2@", Sex: entity.Female, LivingStatus: entity.LivingUnknown}
+	son := &entity.Person{ID: "@I3@", Sex: entity.Male, LivingStatus: entity.Living, Parents: []*entity.Person{grandpa, grandma}}
+	daughter := &entity.Person{ID: "@I4@", Sex: entity.Female, LivingStatus: entity.Living, Parents: []*entity.Person{grandpa, grandma}}
+	sonsWife := &entity.Person{ID: "@I6@", Sex: entity.Female, LivingStatus: entity.Living}
+	sonsSon := &entity.Person{ID: "@I5@", Sex: entity.Male, LivingStatus: entity.Living, Parents: []*entity.Person{son, sonsWife}}
+	sonsDaughter := &entity.Person{ID: "@I7@", Sex: entity.Female, LivingStatus: entity.Living, Parents: []*entity.Person{son, sonsWife}}
+	daughtersHusband := &entity.Person{ID: "@I9@", Sex: entity.Male, LivingStatus: entity.Living}
+	daughtersSon := &entity.Person{ID: "@I8@", Sex: entity.Male, LivingStatus: entity.Living, Parents: []*entity.Person{daughter, daughtersHusband}}
+	daughtersDaughter := &entity.Person{ID: "@I10@", Sex: entity.Female, LivingStatus: entity.Living, Parents: []*entity.Person{daughter, daughtersHusband}}
+	adoptedSon := &entity.Person{
+		ID:              "@I11@",
+		Sex:             entity.Male,
+		LivingStatus:    entity.Living,
+		Parents:         []*entity.Person{son},
+		ParentPedigrees: map[string]entity.Pedigree{son.ID: entity.Adopted},
+	}
+	people := []*entity.Person{grandpa, grandma, son, daughter, sonsSon, sonsWife, sonsDaughter, daughtersSon, daughtersHusband, daughtersDaughter, adoptedSon}
+	graph := NewGraph(people, nil)
+
+	ids := func(people []*entity.Person) []string {
+		out := make([]string, len(people))
+		for i, person := range people {
+			out[i] = person.ID
+		}
+		return out
+	}
+
+	t.Run("PaternalLine", func(t *testing.T) {
+		got, err := graph.PaternalLine(sonsSon.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := []string{sonsSon.ID, son.ID, grandpa.ID}; !slices.Equal(ids(got.Line), exp) {
+			t.Errorf("wrong Line; got %q, expected %q", ids(got.Line), exp)
+		}
+		// The grandfather is deceased, and the adopted son does not inherit
+		// the Y chromosome.
+		if exp := []string{son.ID, sonsSon.ID}; !slices.Equal(ids(got.Carriers), exp) {
+			t.Errorf("wrong Carriers; got %q, expected %q", ids(got.Carriers), exp)
+		}
+	})
+
+	t.Run("MaternalLine", func(t *testing.T) {
+		got, err := graph.MaternalLine(daughtersDaughter.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := []string{daughtersDaughter.ID, daughter.ID, grandma.ID}; !slices.Equal(ids(got.Line), exp) {
+			t.Errorf("wrong Line; got %q, expected %q", ids(got.Line), exp)
+		}
+		// It's unknown if the grandmother is living, so she's left out.
+		if exp := []string{son.ID, daughter.ID, daughtersSon.ID, daughtersDaughter.ID}; !slices.Equal(ids(got.Carriers), exp) {
+			t.Errorf("wrong Carriers; got %q, expected %q", ids(got.Carriers), exp)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := graph.PaternalLine("@I999@"); err == nil {
+			t.Error("expected non-empty error")
+		}
+	})
+
+	t.Run("XRelator", func(t *testing.T) {
+		tests := []struct {
+			Name       string
+			InP1, InP2 string
+			ExpOK      bool
+			ExpDesc    string
+		}{
+			{Name: "daughter to father", InP1: daughter.ID, InP2: grandpa.ID, ExpOK: true, ExpDesc: "daughter"},
+			{Name: "son to father", InP1: son.ID, InP2: grandpa.ID},
+			{Name: "through a daughter", InP1: daughtersSon.ID, InP2: grandpa.ID, ExpOK: true, ExpDesc: "grandson"},
+			{Name: "through a son to his father", InP1: sonsDaughter.ID, InP2: grandpa.ID},
+			{Name: "through a son to his mother", InP1: sonsDaughter.ID, InP2: grandma.ID, ExpOK: true, ExpDesc: "granddaughter"},
+			{Name: "siblings", InP1: sonsSon.ID, InP2: sonsDaughter.ID, ExpOK: true, ExpDesc: "brother"},
+			{Name: "1st cousins through the father", InP1: sonsSon.ID, InP2: daughtersSon.ID},
+		}
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				got, err := graph.XRelator().Relate(context.Background(), test.InP1, test.InP2)
+				if !test.ExpOK {
+					if err == nil {
+						t.Errorf("expected non-empty error, got %q", got.R1.Description)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("expected empty error, got %v", err)
+				}
+				if got.R1.Description != test.ExpDesc {
+					t.Errorf("wrong Description; got %q, expected %q", got.R1.Description, test.ExpDesc)
+				}
+			})
+		}
+	})
+}
@@ -2,10 +2,10 @@ package srv
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"slices"
-	"strconv"
 	"strings"
 	"text/template"
 
@@ -20,8 +20,8 @@ type MermaidFlowchartParams struct {
 	Out       io.Writer
 	Direction string
 	DisplayID bool
-	People    []*entity.Person
-	Unions    []*entity.Union
+	// Graph is the family tree to draw. It's required.
+	Graph *Graph
 }
 
 // ValidFlowchartDirections defines Mermaid-specific orientations for a
@@ -32,6 +32,9 @@ func MakeMermaidFlowchart(ctx context.Context, p MermaidFlowchartParams) error {
 	if !slices.Contains(ValidFlowchartDirections, p.Direction) {
 		return fmt.Errorf("invalid Direction %q, valid ones are: %q", p.Direction, ValidFlowchartDirections)
 	}
+	if p.Graph == nil {
+		return errors.New("Graph is required")
+	}
 
 	tmpl, err := template.New("").Parse(mermaidFlowchartFamilyTree)
 	if err != nil {
@@ -47,8 +50,9 @@ func MakeMermaidFlowchart(ctx context.Context, p MermaidFlowchartParams) error {
 		UnionsByID map[string]*drawUnionOutput
 	}
 
-	allPeopleIDs := make([]string, len(p.People))
-	peopleByID := make(map[string]*drawPersonOutput, len(p.People))
+	graph := p.Graph
+	allPeopleIDs := make([]string, len(graph.People()))
+	peopleByID := make(map[string]*drawPersonOutput, len(graph.People()))
 	unionsByID := make(map[string]*drawUnionOutput)
 
 	// Use this function to prepare ID values for Mermaid diagrams. In
@@ -58,16 +62,14 @@ func MakeMermaidFlowchart(ctx context.Context, p MermaidFlowchartParams) error {
 	// the @ symbol in the node label, just can't put it in the node ID.
 	stripAtSign := func(in string) string { return strings.ReplaceAll(in, "@", "") }
 
-	for _, union := range p.Unions {
-		var p1, p2 string
-		if union.Person1 != nil {
-			p1 = stripAtSign(union.Person1.ID)
-		}
-		if union.Person2 != nil {
-			p2 = stripAtSign(union.Person2.ID)
+	for _, union := range graph.Unions() {
+		// An unknown partner is still drawn, so that there are at least 2.
+		partnerIDs := make([]string, max(len(union.Partners), 2))
+		for i, partner := range union.People() {
+			partnerIDs[i] = stripAtSign(partner.ID)
 		}
 
-		union.ID = stripAtSign(union.ID)
+		unionID := stripAtSign(union.ID)
 		childIDs := make([]string, len(union.Children))
 		for i, child := range union.Children {
 			childIDs[i] = stripAtSign(child.ID)
@@ -76,24 +78,23 @@ func MakeMermaidFlowchart(ctx context.Context, p MermaidFlowchartParams) error {
 		if union.StartDate != nil || union.EndDate != nil {
 			dateSpan = formatDateTuple(union.StartDate) + " - " + formatDateTuple(union.EndDate)
 		}
-		unionsByID[union.ID] = &drawUnionOutput{
-			ID:        union.ID,
-			Person1ID: p1,
-			Person2ID: p2,
-			DateSpan:  dateSpan,
-			ChildIDs:  childIDs,
+		unionsByID[unionID] = &drawUnionOutput{
+			ID:         unionID,
+			PartnerIDs: partnerIDs,
+			DateSpan:   dateSpan,
+			ChildIDs:   childIDs,
 		}
 	}
 
-	for i, person := range p.People {
+	for i, person := range graph.People() {
 		var originalID string
 		if p.DisplayID {
 			// Retain the original ID for display b/c that's the ID used in the Relate people functionality.
 			originalID = person.ID
 		}
 
-		person.ID = stripAtSign(person.ID)
-		allPeopleIDs[i] = person.ID
+		personID := stripAtSign(person.ID)
+		allPeopleIDs[i] = personID
 
 		var abbreviatedName string
 		if person.Name.Forename != "" && person.Name.Surname != "" {
@@ -101,18 +102,18 @@ func MakeMermaidFlowchart(ctx context.Context, p MermaidFlowchartParams) error {
 		}
 
 		var dateSpan string
-		if person.Birthdate != nil || person.Deathdate != nil {
-			dateSpan = formatDateTuple(person.Birthdate) + " - " + formatDateTuple(person.Deathdate)
+		if person.Birthdate != nil || person.Deathdate != nil || person.EstimatedBirthdate != nil || person.EstimatedDeathdate != nil {
+			dateSpan = formatDateOrEstimate(person.Birthdate, person.EstimatedBirthdate) + " - " + formatDateOrEstimate(person.Deathdate, person.EstimatedDeathdate)
 		}
 
 		displayPersonData := drawPersonOutput{
-			ID:              person.ID,
+			ID:              personID,
 			OriginalID:      originalID,
 			Fullname:        strings.ReplaceAll(person.Name.Full(), `"`, `#quot;`),
 			AbbreviatedName: abbreviatedName,
 			DateSpan:        dateSpan,
 		}
-		peopleByID[person.ID] = &displayPersonData
+		peopleByID[personID] = &displayPersonData
 	}
 
 	return tmpl.Execute(p.Out, ExecData{
@@ -132,11 +133,11 @@ type drawPersonOutput struct {
 }
 
 type drawUnionOutput struct {
-	ID        string
-	Person1ID string
-	Person2ID string
-	DateSpan  string
-	ChildIDs  []string
+	ID string
+	// PartnerIDs may have an empty value for an unknown partner.
+	PartnerIDs []string
+	DateSpan   string
+	ChildIDs   []string
 }
 
 const mermaidFlowchartFamilyTree = `flowchart {{$.FlowChartDirection}}
@@ -156,19 +157,18 @@ classDef unionNode height:5rem,width:10rem,display:inline-block;
 %% define unions
 
 {{range $_, $union := $.UnionsByID}}
-	{{- $person1 := index $.PeopleByID $union.Person1ID}}
-	{{- $person2 := index $.PeopleByID $union.Person2ID}}
 
-	%% "{{with $person1}}{{.Fullname}}{{end}}" and "{{with $person2}}{{.Fullname}}{{end}}"
+	%% {{range $i, $id := $union.PartnerIDs}}{{if $i}} and {{end}}"{{with index $.PeopleByID $id}}{{.Fullname}}{{end}}"{{end}}
 	{{$union.ID}}>"
-{{with $person1}}{{.AbbreviatedName}}{{else}}unknown{{end}}
-+
-{{with $person2}}{{.AbbreviatedName}}{{else}}unknown{{end}}
+{{- range $i, $id := $union.PartnerIDs}}
+{{if $i}}+
+{{end}}{{with index $.PeopleByID $id}}{{.AbbreviatedName}}{{else}}unknown{{end}}
+{{- end}}
 {{with $union.DateSpan}}{{.}}{{else}} {{- end}}{{/* as a fallback, leave empty space so that Mermaid can render */}}
 "]:::unionNode
-
-	{{with $union.Person1ID}}{{.}}-...->{{$union.ID}}{{end}}
-	{{with $union.Person2ID}}{{.}}-...->{{$union.ID}}{{end}}
+	{{range $_, $id := $union.PartnerIDs}}
+	{{with $id}}{{.}}-...->{{$union.ID}}{{end}}
+	{{- end}}
 	{{range $_, $childID := $union.ChildIDs}}
 	{{$union.ID}} =====> {{$childID}}
 	{{- end}}
@@ -188,8 +188,27 @@ func formatDateTuple(d *entity.Date) string {
 	return ""
 }
 
+// formatDateOrEstimate prefers the known date d. The estimate is only used if
+// d is empty, and it's clearly marked as an estimate.
+func formatDateOrEstimate(d, estimate *entity.Date) string {
+	if d != nil || estimate == nil {
+		return formatDateTuple(d)
+	}
+
+	if estimate.Date != nil {
+		return "est. " + estimate.Date.FormatYear()
+	} else if estimate.Range != nil && estimate.Range.Lo != nil && estimate.Range.Hi != nil {
+		return "est. " + estimate.Range.Lo.FormatYear() + "..." + estimate.Range.Hi.FormatYear()
+	}
+	return ""
+}
+
 func formatDateYear(d *date.Date) string {
-	y := strconv.Itoa(d.Year)
+	if d.PhraseOnly() {
+		return d.Display
+	}
+
+	y := d.FormatYear()
 	if d.Approximate {
 		return "~ " + y
 	}
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/entity/date"
 )
 
 func TestMakeMermaidFlowchart(t *testing.T) {
@@ -24,28 +25,27 @@ func TestMakeMermaidFlowchart(t *testing.T) {
 		}{
 			{
 				Name:   "no people, no unions",
-				Params: MermaidFlowchartParams{Direction: validDefaultDirection},
+				Params: MermaidFlowchartParams{Direction: validDefaultDirection, Graph: NewGraph(nil, nil)},
 			},
 			{
 				Name: "some people, no unions",
 				Params: MermaidFlowchartParams{
 					Direction: validDefaultDirection,
-					People:    []*entity.Person{{}, {}},
+					Graph:     NewGraph([]*entity.Person{{}, {}}, nil),
 				},
 			},
 			{
 				Name: "no people, some unions",
 				Params: MermaidFlowchartParams{
 					Direction: validDefaultDirection,
-					Unions:    []*entity.Union{{}, {}},
+					Graph:     NewGraph(nil, []*entity.Union{{}, {}}),
 				},
 			},
 			{
 				Name: "some people, some unions",
 				Params: MermaidFlowchartParams{
 					Direction: validDefaultDirection,
-					People:    []*entity.Person{{}, {}},
-					Unions:    []*entity.Union{{}, {}},
+					Graph:     NewGraph([]*entity.Person{{}, {}}, []*entity.Union{{}, {}}),
 				},
 			},
 		}
@@ -83,7 +83,7 @@ func TestMakeMermaidFlowchart(t *testing.T) {
 				Direction: validDefaultDirection,
 				Out:       sink,
 				DisplayID: displayID,
-				People:    people,
+				Graph:     NewGraph(people, nil),
 			})
 			if err != nil {
 				t.Fatal(err)
@@ -108,12 +108,94 @@ func TestMakeMermaidFlowchart(t *testing.T) {
 		}
 	})
 
+	t.Run("unions with any number of partners", func(t *testing.T) {
+		sink := new(strings.Builder)
+
+		people := []*entity.Person{
+			{ID: "@I1@", Name: entity.PersonalName{Forename: "Alpha", Surname: "Foo"}},
+			{ID: "@I2@", Name: entity.PersonalName{Forename: "Bravo", Surname: "Foo"}},
+			{ID: "@I3@", Name: entity.PersonalName{Forename: "Charlie", Surname: "Foo"}},
+		}
+		unions := []*entity.Union{
+			{
+				ID: "@F1@",
+				Partners: []*entity.Partner{
+					{Person: &entity.Person{ID: "@I1@"}, Role: entity.Husband},
+					{Person: &entity.Person{ID: "@I2@"}, Role: entity.Wife},
+					{Person: &entity.Person{ID: "@I3@"}},
+				},
+			},
+			{ID: "@F2@", Partners: []*entity.Partner{{Person: &entity.Person{ID: "@I1@"}}}},
+		}
+		err := MakeMermaidFlowchart(context.Background(), MermaidFlowchartParams{
+			Direction: validDefaultDirection,
+			Out:       sink,
+			Graph:     NewGraph(people, unions),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := sink.String()
+		for _, exp := range []string{
+			"A. Foo\n+\nB. Foo\n+\nC. Foo",
+			"I1-...->F1", "I2-...->F1", "I3-...->F1",
+			"A. Foo\n+\nunknown",
+			"I1-...->F2",
+		} {
+			if !strings.Contains(got, exp) {
+				t.Errorf("expected output to contain %q", exp)
+			}
+		}
+		if t.Failed() {
+			t.Logf("for reference, here is flowchart\n%s", got)
+		}
+	})
+
+	t.Run("estimated dates", func(t *testing.T) {
+		sink := new(strings.Builder)
+
+		birthdate, err := entity.NewDate(nil, &date.Range{
+			Lo: &date.Date{Year: 1816, Approximate: true, Qualifier: "EST"},
+			Hi: &date.Date{Year: 1824, Approximate: true, Qualifier: "EST"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		deathdate, err := entity.NewDate(&date.Date{Year: 1890}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		estimatedDeathdate, err := entity.NewDate(&date.Date{Year: 1900, Approximate: true, Qualifier: "EST"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		people := []*entity.Person{
+			{ID: "@IFoo@", EstimatedBirthdate: birthdate, Deathdate: deathdate, EstimatedDeathdate: estimatedDeathdate},
+		}
+		err = MakeMermaidFlowchart(context.Background(), MermaidFlowchartParams{
+			Direction: validDefaultDirection,
+			Out:       sink,
+			Graph:     NewGraph(people, nil),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := sink.String()
+		if !strings.Contains(got, "est. 1816...1824 - 1890") {
+			t.Errorf("expected output to have estimated birth and known death")
+			t.Logf("for reference, here is flowchart\n%s", got)
+		}
+	})
+
 	t.Run("Direction", func(t *testing.T) {
 		for _, direction := range ValidFlowchartDirections {
 			t.Run(direction, func(t *testing.T) {
 				sink := new(bytes.Buffer)
 
-				err := MakeMermaidFlowchart(context.Background(), MermaidFlowchartParams{Direction: direction, Out: sink})
+				err := MakeMermaidFlowchart(context.Background(), MermaidFlowchartParams{Direction: direction, Out: sink, Graph: NewGraph(nil, nil)})
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -137,7 +219,7 @@ func TestMakeMermaidFlowchart(t *testing.T) {
 		t.Run("error", func(t *testing.T) {
 			sink := new(bytes.Buffer)
 
-			err := MakeMermaidFlowchart(context.Background(), MermaidFlowchartParams{Direction: "invalid", Out: sink})
+			err := MakeMermaidFlowchart(context.Background(), MermaidFlowchartParams{Direction: "invalid", Out: sink, Graph: NewGraph(nil, nil)})
 			if err == nil {
 				t.Error("expected an error but got nil")
 			} else if !strings.Contains(err.Error(), "invalid Direction") {
@@ -153,6 +235,13 @@ func TestMakeMermaidFlowchart(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("no Graph", func(t *testing.T) {
+		err := MakeMermaidFlowchart(context.Background(), MermaidFlowchartParams{Direction: validDefaultDirection, Out: new(bytes.Buffer)})
+		if err == nil {
+			t.Error("expected an error but got nil")
+		}
+	})
 }
 
 func TestMermaidRenderer(t *testing.T) {
@@ -199,7 +288,7 @@ func TestDraw(t *testing.T) {
 		}
 		defer func() { _ = file.Close() }()
 
-		people, unions, err := ParseGedcom(ctx, file)
+		people, unions, err := ParseGedcom(ctx, file, date.ParseOptions{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -209,8 +298,7 @@ func TestDraw(t *testing.T) {
 			Out:       buf,
 			Direction: "LR",
 			DisplayID: true,
-			People:    people,
-			Unions:    unions,
+			Graph:     NewGraph(people, unions),
 		}
 		if err = MakeMermaidFlowchart(ctx, params); err != nil {
 			t.Fatal(err)
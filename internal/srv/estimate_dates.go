@@ -0,0 +1,329 @@
+package srv
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+	"github.com/rafaelespinoza/ged/internal/log"
+)
+
+// EstimateParams are the assumptions for estimating dates. Each value is a
+// number of years. A zero value is replaced with the value from
+// DefaultEstimateParams.
+type EstimateParams struct {
+	// MinGeneration and MaxGeneration are the youngest and oldest ages of a
+	// parent at the birth of a child. They also bound the age of a person at
+	// the start of a union.
+	MinGeneration, MaxGeneration int
+	// MaxSpouseAgeGap is the most years between the births of partners.
+	MaxSpouseAgeGap int
+	// MaxLifespan is the oldest age of a person at death.
+	MaxLifespan int
+}
+
+// DefaultEstimateParams are some generous assumptions, so that the estimated
+// range is more likely to contain the actual date.
+var DefaultEstimateParams = EstimateParams{
+	MinGeneration:   16,
+	MaxGeneration:   50,
+	MaxSpouseAgeGap: 20,
+	MaxLifespan:     100,
+}
+
+func (p EstimateParams) withDefaults() EstimateParams {
+	if p.MinGeneration == 0 {
+		p.MinGeneration = DefaultEstimateParams.MinGeneration
+	}
+	if p.MaxGeneration == 0 {
+		p.MaxGeneration = DefaultEstimateParams.MaxGeneration
+	}
+	if p.MaxSpouseAgeGap == 0 {
+		p.MaxSpouseAgeGap = DefaultEstimateParams.MaxSpouseAgeGap
+	}
+	if p.MaxLifespan == 0 {
+		p.MaxLifespan = DefaultEstimateParams.MaxLifespan
+	}
+	return p
+}
+
+// estimatePhrase is the Phrase of every estimated date, so that it's clearly
+// marked as such wherever it's displayed.
+const estimatePhrase = "estimated"
+
+// EstimateDates infers a range of years for the birth and death of each
+// person who does not have one, using the dates of their parents, partners,
+// children and unions. The estimates go into the fields EstimatedBirthdate and
+// EstimatedDeathdate, so known dates are never replaced. Each bound of an
+// estimate has the EST qualifier.
+//
+// A birth is estimated from known dates first. Then, those estimates help
+// estimate the births of more distant relatives, and so on, until the
+// estimates stop changing. A person whose constraints conflict gets no
+// estimate at all. A death is only estimated if the person is known to be
+// deceased, such as from a burial, or if they would be older than
+// params.MaxLifespan by now. Otherwise, they might still be living.
+func EstimateDates(ctx context.Context, people []*entity.Person, unions []*entity.Union, params EstimateParams) {
+	e := newDateEstimator(people, unions, params.withDefaults())
+
+	// Each round only uses the estimates from the previous rounds, so that the
+	// outcome does not depend on the order of the people. An estimate may be
+	// narrowed by later rounds, as more relatives have estimates.
+	for round := 0; round < len(people); round++ {
+		found := make(map[string]yearRange)
+		for _, person := range people {
+			if e.known[person.ID] || e.conflicting[person.ID] {
+				continue
+			}
+			rng, ok := e.estimateBirth(ctx, person)
+			if prev, estimated := e.births[person.ID]; ok && (!estimated || rng != prev) {
+				found[person.ID] = rng
+			}
+		}
+		// An estimate from a previous round is dropped once it conflicts with
+		// the newer estimates of relatives.
+		dropped := 0
+		for id := range e.conflicting {
+			if _, ok := e.births[id]; ok {
+				delete(e.births, id)
+				dropped++
+			}
+		}
+		if len(found) < 1 && dropped < 1 {
+			break
+		}
+		for id, rng := range found {
+			e.births[id] = rng
+		}
+	}
+
+	for _, person := range people {
+		if _, ok := e.births[person.ID]; ok && !e.known[person.ID] {
+			person.EstimatedBirthdate = newEstimate(ctx, e.births[person.ID])
+		}
+		if !hasYears(person.Deathdate) {
+			if rng, ok := e.estimateDeath(person); ok {
+				person.EstimatedDeathdate = newEstimate(ctx, rng)
+			}
+		}
+	}
+}
+
+// yearRange is an inclusive range of years.
+type yearRange struct{ lo, hi int }
+
+type dateEstimator struct {
+	params     EstimateParams
+	peopleByID map[string]*entity.Person
+	// births are the known, or estimated, years of birth by person ID.
+	births map[string]yearRange
+	// known tells which of the births are from known dates, rather than
+	// estimates.
+	known map[string]bool
+	// conflicting tells which people have constraints on their birth that
+	// cannot all be true. More constraints would not help, so they're skipped.
+	conflicting map[string]bool
+	// unionStarts are the years that each person entered a union, by person
+	// ID.
+	unionStarts map[string][]yearRange
+	thisYear    int
+}
+
+func newDateEstimator(people []*entity.Person, unions []*entity.Union, params EstimateParams) *dateEstimator {
+	out := dateEstimator{
+		params:      params,
+		peopleByID:  make(map[string]*entity.Person, len(people)),
+		births:      make(map[string]yearRange, len(people)),
+		known:       make(map[string]bool),
+		conflicting: make(map[string]bool),
+		unionStarts: make(map[string][]yearRange),
+		thisYear:    time.Now().Year(),
+	}
+
+	for _, person := range people {
+		out.peopleByID[person.ID] = person
+		if rng, ok := closedYears(person.Birthdate); ok {
+			out.births[person.ID] = rng
+			out.known[person.ID] = true
+		}
+	}
+
+	for _, union := range unions {
+		rng, ok := closedYears(union.StartDate)
+		if !ok {
+			continue
+		}
+		for _, partner := range union.People() {
+			out.unionStarts[partner.ID] = append(out.unionStarts[partner.ID], rng)
+		}
+	}
+
+	return &out
+}
+
+// estimateBirth intersects the constraints on the birth of the person. The
+// output is not ok if there are no constraints, or if they conflict.
+func (e *dateEstimator) estimateBirth(ctx context.Context, person *entity.Person) (out yearRange, ok bool) {
+	p := e.params
+	var c yearConstraints
+
+	for _, parent := range person.Parents {
+		if rng, found := e.births[parent.ID]; found {
+			c.add(rng.lo+p.MinGeneration, rng.hi+p.MaxGeneration)
+		}
+		if death, found := closedYears(e.lookup(parent).Deathdate); found {
+			// A father might die before the birth of his child.
+			c.addHi(death.hi + 1)
+		}
+	}
+	for _, child := range person.Children {
+		if rng, found := e.births[child.ID]; found {
+			c.add(rng.lo-p.MaxGeneration, rng.hi-p.MinGeneration)
+		}
+	}
+	for _, spouse := range person.Spouses {
+		if rng, found := e.births[spouse.ID]; found {
+			c.add(rng.lo-p.MaxSpouseAgeGap, rng.hi+p.MaxSpouseAgeGap)
+		}
+	}
+	for _, rng := range e.unionStarts[person.ID] {
+		c.add(rng.lo-p.MaxGeneration, rng.hi-p.MinGeneration)
+	}
+	if death, found := closedYears(person.Deathdate); found {
+		c.add(death.lo-p.MaxLifespan, death.hi)
+	}
+
+	if !c.hasLo || !c.hasHi {
+		return
+	}
+	out = yearRange{lo: c.lo, hi: min(c.hi, e.thisYear)}
+	if out.lo > out.hi {
+		log.Warn(ctx, map[string]any{"person_id": person.ID, "lo": out.lo, "hi": out.hi}, "conflicting dates of relatives, not estimating birth")
+		e.conflicting[person.ID] = true
+		return
+	}
+	ok = true
+	return
+}
+
+// estimateDeath is between the birth of the person and the end of their
+// lifespan. It's moved earlier by a dated burial, and later by the events that
+// the person was alive for. The output is not ok if the person might still be
+// living.
+func (e *dateEstimator) estimateDeath(person *entity.Person) (out yearRange, ok bool) {
+	birth, found := e.births[person.ID]
+	if !found {
+		return
+	}
+	out = yearRange{lo: birth.lo, hi: birth.hi + e.params.MaxLifespan}
+
+	var deceased bool
+	for _, event := range person.Events {
+		if !isDeceasedEvent(event) {
+			continue
+		}
+		deceased = true
+		if _, hi, _, hasHi := event.Date.Years(); hasHi {
+			out.hi = min(out.hi, hi)
+		}
+	}
+	if deceased {
+		out.hi = min(out.hi, e.thisYear)
+	} else if out.hi >= e.thisYear {
+		return
+	}
+
+	for _, child := range person.Children {
+		if rng, found := e.births[child.ID]; found {
+			out.lo = max(out.lo, rng.lo-1)
+		}
+	}
+	for _, rng := range e.unionStarts[person.ID] {
+		out.lo = max(out.lo, rng.lo)
+	}
+	ok = out.lo <= out.hi
+	return
+}
+
+// lookup finds the full version of a related person, which could have more
+// data than the simplified version in the Parents, Children, Spouses fields.
+func (e *dateEstimator) lookup(person *entity.Person) *entity.Person {
+	if full, ok := e.peopleByID[person.ID]; ok {
+		return full
+	}
+	return person
+}
+
+// yearConstraints is the intersection of some ranges of years.
+type yearConstraints struct {
+	lo, hi       int
+	hasLo, hasHi bool
+}
+
+func (c *yearConstraints) add(lo, hi int) {
+	c.addLo(lo)
+	c.addHi(hi)
+}
+
+func (c *yearConstraints) addLo(lo int) {
+	if !c.hasLo || lo > c.lo {
+		c.lo, c.hasLo = lo, true
+	}
+}
+
+func (c *yearConstraints) addHi(hi int) {
+	if !c.hasHi || hi < c.hi {
+		c.hi, c.hasHi = hi, true
+	}
+}
+
+// closedYears is the range of years of d, but only if both bounds are known.
+func closedYears(d *entity.Date) (out yearRange, ok bool) {
+	lo, hi, hasLo, hasHi := d.Years()
+	if !hasLo || !hasHi {
+		return
+	}
+	return yearRange{lo: lo, hi: hi}, true
+}
+
+func hasYears(d *entity.Date) bool {
+	_, _, hasLo, hasHi := d.Years()
+	return hasLo || hasHi
+}
+
+// newEstimate makes an estimated date out of rng. It's a Date if the range is
+// only one year, otherwise it's a Range.
+func newEstimate(ctx context.Context, rng yearRange) *entity.Date {
+	lo, err := parseEstimate(rng.lo)
+	if err != nil {
+		log.Error(ctx, map[string]any{"year": rng.lo}, err, "could not make estimate")
+		return nil
+	}
+	if rng.lo == rng.hi {
+		lo.SetPhrase(estimatePhrase)
+		out, _ := entity.NewDate(lo, nil)
+		return out
+	}
+
+	hi, err := parseEstimate(rng.hi)
+	if err != nil {
+		log.Error(ctx, map[string]any{"year": rng.hi}, err, "could not make estimate")
+		return nil
+	}
+	r := &date.Range{Lo: lo, Hi: hi, Phrase: estimatePhrase}
+	r.Payload = r.Format()
+	out, _ := entity.NewDate(nil, r)
+	return out
+}
+
+func parseEstimate(year int) (*date.Date, error) {
+	in := "EST " + strconv.Itoa(year)
+	if year < 1 {
+		// The year is astronomical.
+		in = "EST " + strconv.Itoa(1-year) + " BCE"
+	}
+	out, _, err := date.Parse(in)
+	return out, err
+}
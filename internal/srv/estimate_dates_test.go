@@ -0,0 +1,119 @@
+package srv
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+func TestEstimateDates(t *testing.T) {
+	grandparent := &entity.Person{ID: "@I1@", Birthdate: MustParseDate(t, "1 JAN 1800"), Deathdate: MustParseDate(t, "1870")}
+	parent := &entity.Person{ID: "@I2@"}
+	partner := &entity.Person{ID: "@I3@"}
+	child := &entity.Person{ID: "@I4@"}
+	recent := &entity.Person{ID: "@I5@", Birthdate: MustParseDate(t, "1990")}
+	recentChild := &entity.Person{ID: "@I6@"}
+	loner := &entity.Person{ID: "@I7@"}
+	buried := &entity.Person{ID: "@I8@", Birthdate: MustParseDate(t, "1950"), Events: []*entity.Event{{Type: "Burial", Date: MustParseDate(t, "1990")}}}
+	buriedUndated := &entity.Person{ID: "@I9@", Birthdate: MustParseDate(t, "1950"), Events: []*entity.Event{{Type: "Burial"}}}
+
+	grandparent.Children = []*entity.Person{parent}
+	parent.Parents = []*entity.Person{grandparent}
+	parent.Spouses = []*entity.Person{partner}
+	parent.Children = []*entity.Person{child}
+	partner.Spouses = []*entity.Person{parent}
+	partner.Children = []*entity.Person{child}
+	child.Parents = []*entity.Person{parent, partner}
+	recent.Children = []*entity.Person{recentChild}
+	recentChild.Parents = []*entity.Person{recent}
+
+	people := []*entity.Person{grandparent, parent, partner, child, recent, recentChild, loner, buried, buriedUndated}
+	unions := []*entity.Union{
+		{ID: "@F1@", Partners: []*entity.Partner{{Person: parent}, {Person: partner}}, StartDate: MustParseDate(t, "1840"), Children: []*entity.Person{child}},
+	}
+
+	EstimateDates(context.Background(), people, unions, EstimateParams{})
+
+	tests := []struct {
+		Person   *entity.Person
+		ExpBirth string
+		ExpDeath string
+	}{
+		{Person: grandparent},
+		{Person: parent, ExpBirth: "BET 1816 AND 1824", ExpDeath: "BET 1840 AND 1924"},
+		{Person: partner, ExpBirth: "BET 1796 AND 1824", ExpDeath: "BET 1840 AND 1924"},
+		{Person: child, ExpBirth: "BET 1832 AND 1874", ExpDeath: "BET 1832 AND 1974"},
+		{Person: recent},
+		{Person: recentChild, ExpBirth: "BET 2006 AND " + strconv.Itoa(min(2040, time.Now().Year()))},
+		{Person: loner},
+		{Person: buried, ExpDeath: "BET 1950 AND 1990"},
+		{Person: buriedUndated, ExpDeath: "BET 1950 AND " + strconv.Itoa(time.Now().Year())},
+	}
+
+	for _, test := range tests {
+		got := test.Person.EstimatedBirthdate
+		if test.ExpBirth == "" && got != nil {
+			t.Errorf("%s; expected empty EstimatedBirthdate, got %q", test.Person.ID, got)
+		} else if test.ExpBirth != "" {
+			testEstimate(t, test.Person.ID+".EstimatedBirthdate", got, test.ExpBirth)
+		}
+
+		got = test.Person.EstimatedDeathdate
+		if test.ExpDeath == "" && got != nil {
+			t.Errorf("%s; expected empty EstimatedDeathdate, got %q", test.Person.ID, got)
+		} else if test.ExpDeath != "" {
+			testEstimate(t, test.Person.ID+".EstimatedDeathdate", got, test.ExpDeath)
+		}
+	}
+
+	if grandparent.Birthdate.Date.Format() != "1 JAN 1800" {
+		t.Errorf("known Birthdate should not change, got %q", grandparent.Birthdate.Date.Format())
+	}
+}
+
+func TestEstimateDatesConflicting(t *testing.T) {
+	// The first round estimates the birth of the parent from their child, and
+	// the birth of the grandparent from their partner. Those two estimates
+	// conflict in the next round, so neither one is kept.
+	grandparent := &entity.Person{ID: "@I1@"}
+	grandparentPartner := &entity.Person{ID: "@I2@", Birthdate: MustParseDate(t, "1890")}
+	parent := &entity.Person{ID: "@I3@"}
+	child := &entity.Person{ID: "@I4@", Birthdate: MustParseDate(t, "1900")}
+
+	grandparent.Spouses = []*entity.Person{grandparentPartner}
+	grandparent.Children = []*entity.Person{parent}
+	grandparentPartner.Spouses = []*entity.Person{grandparent}
+	parent.Parents = []*entity.Person{grandparent}
+	parent.Children = []*entity.Person{child}
+	child.Parents = []*entity.Person{parent}
+
+	people := []*entity.Person{grandparent, grandparentPartner, parent, child}
+	EstimateDates(context.Background(), people, nil, EstimateParams{})
+
+	for _, person := range []*entity.Person{grandparent, parent} {
+		if person.EstimatedBirthdate != nil {
+			t.Errorf("%s; expected empty EstimatedBirthdate, got %q", person.ID, person.EstimatedBirthdate)
+		}
+	}
+}
+
+func testEstimate(t *testing.T, prefix string, got *entity.Date, exp string) {
+	t.Helper()
+
+	if got == nil || got.Range == nil {
+		t.Errorf("%s; expected a Range, got %v", prefix, got)
+		return
+	}
+	if got.Range.Format() != exp {
+		t.Errorf("%s; wrong date, got %q, expected %q", prefix, got.Range.Format(), exp)
+	}
+	if got.Range.Phrase != estimatePhrase {
+		t.Errorf("%s; wrong Phrase, got %q, expected %q", prefix, got.Range.Phrase, estimatePhrase)
+	}
+	if got.Range.Lo.Qualifier != "EST" || got.Range.Hi.Qualifier != "EST" {
+		t.Errorf("%s; expected EST qualifiers, got %q, %q", prefix, got.Range.Lo.Qualifier, got.Range.Hi.Qualifier)
+	}
+}
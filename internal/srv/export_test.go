@@ -0,0 +1,46 @@
+package srv
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+	"github.com/rafaelespinoza/ged/internal/log"
+)
+
+// errorLogs counts the messages logged at the ERROR level, so that a test can
+// check that it did not log any.
+var errorLogs atomic.Int64
+
+type countingHandler struct{ slog.Handler }
+
+func (h countingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= slog.LevelError {
+		errorLogs.Add(1)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestMain(m *testing.M) {
+	log.Init(countingHandler{slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})})
+	os.Exit(m.Run())
+}
+
+// MustParseDate interprets in as a date or a date range, for test data. It's
+// exported so that the tests in package srv_test can use it too.
+func MustParseDate(t *testing.T, in string) *entity.Date {
+	t.Helper()
+	dat, rng, err := date.Parse(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := entity.NewDate(dat, rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
@@ -0,0 +1,229 @@
+package srv
+
+import (
+	"slices"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+// Graph is an index of the people and unions of a family tree. Build it once,
+// such as from the output of ParseGedcom, then share it with anything that
+// needs to look up or traverse the tree.
+//
+// The connections between people come from both the relatives of each Person
+// and the partners and children of each Union. So a Graph can also be built
+// from people without unions, such as the JSON input for relating people.
+type Graph struct {
+	people     []*entity.Person
+	unions     []*entity.Union
+	peopleByID map[string]*entity.Person
+	unionsByID map[string]*entity.Union
+
+	// These are keyed by person ID. The values are IDs, in the order that
+	// they're first found.
+	parentIDs map[string][]string
+	childIDs  map[string][]string
+	spouseIDs map[string][]string
+
+	// These are keyed by person ID. The values are union IDs.
+	unionIDsAsPartner map[string][]string
+	unionIDsAsChild   map[string][]string
+}
+
+// NewGraph indexes the people and unions. The inputs are not modified.
+func NewGraph(people []*entity.Person, unions []*entity.Union) *Graph {
+	out := Graph{
+		people:            people,
+		unions:            unions,
+		peopleByID:        make(map[string]*entity.Person, len(people)),
+		unionsByID:        make(map[string]*entity.Union, len(unions)),
+		parentIDs:         make(map[string][]string, len(people)),
+		childIDs:          make(map[string][]string, len(people)),
+		spouseIDs:         make(map[string][]string, len(people)),
+		unionIDsAsPartner: make(map[string][]string),
+		unionIDsAsChild:   make(map[string][]string),
+	}
+
+	for _, person := range people {
+		out.peopleByID[person.ID] = person
+	}
+
+	for _, person := range people {
+		for _, parent := range person.Parents {
+			out.addParent(parent.ID, person.ID)
+		}
+		for _, child := range person.Children {
+			out.addParent(person.ID, child.ID)
+		}
+		for _, spouse := range person.Spouses {
+			out.addSpouses(person.ID, spouse.ID)
+		}
+	}
+
+	for _, union := range unions {
+		out.unionsByID[union.ID] = union
+		partners := union.People()
+		for i, partner := range partners {
+			out.unionIDsAsPartner[partner.ID] = appendUnique(out.unionIDsAsPartner[partner.ID], union.ID)
+			for _, other := range partners[i+1:] {
+				out.addSpouses(partner.ID, other.ID)
+			}
+			for _, child := range union.Children {
+				out.addParent(partner.ID, child.ID)
+			}
+		}
+		for _, child := range union.Children {
+			out.unionIDsAsChild[child.ID] = appendUnique(out.unionIDsAsChild[child.ID], union.ID)
+		}
+	}
+
+	return &out
+}
+
+func (g *Graph) addParent(parentID, childID string) {
+	g.parentIDs[childID] = appendUnique(g.parentIDs[childID], parentID)
+	g.childIDs[parentID] = appendUnique(g.childIDs[parentID], childID)
+}
+
+func (g *Graph) addSpouses(id1, id2 string) {
+	if id1 == id2 {
+		return
+	}
+	g.spouseIDs[id1] = appendUnique(g.spouseIDs[id1], id2)
+	g.spouseIDs[id2] = appendUnique(g.spouseIDs[id2], id1)
+}
+
+func appendUnique(ids []string, id string) []string {
+	if slices.Contains(ids, id) {
+		return ids
+	}
+	return append(ids, id)
+}
+
+// People are all of the people in the Graph, in their original order.
+func (g *Graph) People() []*entity.Person { return g.people }
+
+// Unions are all of the unions in the Graph, in their original order.
+func (g *Graph) Unions() []*entity.Union { return g.unions }
+
+// Person looks up a person by ID.
+func (g *Graph) Person(id string) (out *entity.Person, found bool) {
+	out, found = g.peopleByID[id]
+	return
+}
+
+// Union looks up a union by ID.
+func (g *Graph) Union(id string) (out *entity.Union, found bool) {
+	out, found = g.unionsByID[id]
+	return
+}
+
+// Parents are the parents of the person at id.
+func (g *Graph) Parents(id string) []*entity.Person { return g.lookupAll(g.parentIDs[id]) }
+
+// Children are the children of the person at id.
+func (g *Graph) Children(id string) []*entity.Person { return g.lookupAll(g.childIDs[id]) }
+
+// Spouses are the people who have been a spouse, or any other partner, of the
+// person at id.
+func (g *Graph) Spouses(id string) []*entity.Person { return g.lookupAll(g.spouseIDs[id]) }
+
+// IsSpouse tells if the people at id1 and id2 have been partners.
+func (g *Graph) IsSpouse(id1, id2 string) bool { return slices.Contains(g.spouseIDs[id1], id2) }
+
+// Pedigree tells how the person at childID is a child of the person at
+// parentID. It's empty if it's unknown, which usually means a birth.
+func (g *Graph) Pedigree(childID, parentID string) entity.Pedigree {
+	if child, ok := g.peopleByID[childID]; ok {
+		return child.ParentPedigrees[parentID]
+	}
+	return ""
+}
+
+// Siblings are the other children of any parent of the person at id. So half
+// siblings are included.
+func (g *Graph) Siblings(id string) []*entity.Person {
+	var ids []string
+	for _, parentID := range g.parentIDs[id] {
+		for _, siblingID := range g.childIDs[parentID] {
+			if siblingID != id {
+				ids = appendUnique(ids, siblingID)
+			}
+		}
+	}
+	return g.lookupAll(ids)
+}
+
+// UnionsAsPartner are the unions where the person at id is a partner.
+func (g *Graph) UnionsAsPartner(id string) []*entity.Union {
+	return g.lookupUnions(g.unionIDsAsPartner[id])
+}
+
+// UnionsAsChild are the unions where the person at id is a child.
+func (g *Graph) UnionsAsChild(id string) []*entity.Union {
+	return g.lookupUnions(g.unionIDsAsChild[id])
+}
+
+// Ancestors visits the ancestors of the person at id, nearest generations
+// first. The depth of a parent is 1, a grandparent is 2, and so on. An
+// ancestor is only visited once, at the nearest depth, even if they're an
+// ancestor in more than one way. Visiting stops when yield returns false, or
+// after maxDepth generations. A maxDepth less than 1 is unlimited.
+func (g *Graph) Ancestors(id string, maxDepth int, yield func(person *entity.Person, depth int) bool) {
+	g.walk(id, g.parentIDs, maxDepth, yield)
+}
+
+// Descendants is like Ancestors, but it visits children, then grandchildren,
+// and so on.
+func (g *Graph) Descendants(id string, maxDepth int, yield func(person *entity.Person, depth int) bool) {
+	g.walk(id, g.childIDs, maxDepth, yield)
+}
+
+// walk is a breadth-first traversal of the edges, starting from the person at
+// id, who is not visited.
+func (g *Graph) walk(id string, edges map[string][]string, maxDepth int, yield func(*entity.Person, int) bool) {
+	visited := map[string]bool{id: true}
+	current := []string{id}
+
+	for depth := 1; len(current) > 0 && (maxDepth < 1 || depth <= maxDepth); depth++ {
+		var next []string
+		for _, currID := range current {
+			for _, nextID := range edges[currID] {
+				if visited[nextID] {
+					continue
+				}
+				visited[nextID] = true
+				next = append(next, nextID)
+
+				person, ok := g.peopleByID[nextID]
+				if !ok {
+					continue
+				}
+				if !yield(person, depth) {
+					return
+				}
+			}
+		}
+		current = next
+	}
+}
+
+func (g *Graph) lookupAll(ids []string) []*entity.Person {
+	out := make([]*entity.Person, 0, len(ids))
+	for _, id := range ids {
+		if person, ok := g.peopleByID[id]; ok {
+			out = append(out, person)
+		}
+	}
+	return out
+}
+
+func (g *Graph) lookupUnions(ids []string) []*entity.Union {
+	out := make([]*entity.Union, 0, len(ids))
+	for _, id := range ids {
+		if union, ok := g.unionsByID[id]; ok {
+			out = append(out, union)
+		}
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package srv
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+func TestGraph(t *testing.T) {
+	// Two generations of a family. The grandparents, @I1@ and @I2@, have the
+	// children @I3@ and @I4@. Then @I3@ and @I5@ have the child @I6@. The
+	// person @I4@ only has the relatives on the Person, there is no Union.
+	grandpa := &entity.Person{ID: "@I1@"}
+	grandma := &entity.Person{ID: "@I2@"}
+	parent := &entity.Person{ID: "@I3@"}
+	aunt := &entity.Person{ID: "@I4@", Parents: []*entity.Person{{ID: "@I1@"}}}
+	partner := &entity.Person{ID: "@I5@"}
+	child := &entity.Person{ID: "@I6@"}
+
+	people := []*entity.Person{grandpa, grandma, parent, aunt, partner, child}
+	unions := []*entity.Union{
+		{
+			ID:       "@F1@",
+			Partners: []*entity.Partner{{Person: grandpa, Role: entity.Husband}, {Person: grandma, Role: entity.Wife}},
+			Children: []*entity.Person{parent},
+		},
+		{
+			ID:       "@F2@",
+			Partners: []*entity.Partner{{Person: parent}, {Person: partner}},
+			Children: []*entity.Person{child},
+		},
+	}
+
+	graph := NewGraph(people, unions)
+
+	ids := func(in []*entity.Person) []string {
+		out := make([]string, len(in))
+		for i, person := range in {
+			out[i] = person.ID
+		}
+		return out
+	}
+	unionIDs := func(in []*entity.Union) []string {
+		out := make([]string, len(in))
+		for i, union := range in {
+			out[i] = union.ID
+		}
+		return out
+	}
+	testIDs := func(t *testing.T, name string, got, exp []string) {
+		t.Helper()
+		if !slices.Equal(got, exp) {
+			t.Errorf("wrong %s; got %q, expected %q", name, got, exp)
+		}
+	}
+
+	t.Run("lookups", func(t *testing.T) {
+		if got, ok := graph.Person("@I3@"); !ok || got != parent {
+			t.Errorf("wrong Person; got %v, %t", got, ok)
+		}
+		if _, ok := graph.Person("@I99@"); ok {
+			t.Error("expected not to find Person")
+		}
+		if got, ok := graph.Union("@F2@"); !ok || got != unions[1] {
+			t.Errorf("wrong Union; got %v, %t", got, ok)
+		}
+	})
+
+	t.Run("relatives", func(t *testing.T) {
+		testIDs(t, "Parents", ids(graph.Parents("@I3@")), []string{"@I1@", "@I2@"})
+		testIDs(t, "Parents", ids(graph.Parents("@I4@")), []string{"@I1@"})
+		testIDs(t, "Children", ids(graph.Children("@I1@")), []string{"@I4@", "@I3@"})
+		testIDs(t, "Spouses", ids(graph.Spouses("@I3@")), []string{"@I5@"})
+		testIDs(t, "Siblings", ids(graph.Siblings("@I3@")), []string{"@I4@"})
+		testIDs(t, "Siblings", ids(graph.Siblings("@I6@")), []string{})
+		if !graph.IsSpouse("@I5@", "@I3@") {
+			t.Error("expected spouses")
+		}
+		if graph.IsSpouse("@I1@", "@I3@") {
+			t.Error("expected not spouses")
+		}
+	})
+
+	t.Run("unions", func(t *testing.T) {
+		testIDs(t, "UnionsAsPartner", unionIDs(graph.UnionsAsPartner("@I3@")), []string{"@F2@"})
+		testIDs(t, "UnionsAsChild", unionIDs(graph.UnionsAsChild("@I3@")), []string{"@F1@"})
+		testIDs(t, "UnionsAsChild", unionIDs(graph.UnionsAsChild("@I4@")), []string{})
+	})
+
+	t.Run("ancestors", func(t *testing.T) {
+		var got []string
+		var depths []int
+		graph.Ancestors("@I6@", 0, func(person *entity.Person, depth int) bool {
+			got = append(got, person.ID)
+			depths = append(depths, depth)
+			return true
+		})
+		testIDs(t, "Ancestors", got, []string{"@I3@", "@I5@", "@I1@", "@I2@"})
+		if !slices.Equal(depths, []int{1, 1, 2, 2}) {
+			t.Errorf("wrong depths; got %v", depths)
+		}
+
+		got = nil
+		graph.Ancestors("@I6@", 1, func(person *entity.Person, depth int) bool {
+			got = append(got, person.ID)
+			return true
+		})
+		testIDs(t, "Ancestors with max depth", got, []string{"@I3@", "@I5@"})
+
+		got = nil
+		graph.Ancestors("@I6@", 0, func(person *entity.Person, depth int) bool {
+			got = append(got, person.ID)
+			return len(got) < 3
+		})
+		testIDs(t, "Ancestors stopped early", got, []string{"@I3@", "@I5@", "@I1@"})
+	})
+
+	t.Run("descendants", func(t *testing.T) {
+		var got []string
+		var depths []int
+		graph.Descendants("@I1@", 0, func(person *entity.Person, depth int) bool {
+			got = append(got, person.ID)
+			depths = append(depths, depth)
+			return true
+		})
+		testIDs(t, "Descendants", got, []string{"@I4@", "@I3@", "@I6@"})
+		if !slices.Equal(depths, []int{1, 1, 2}) {
+			t.Errorf("wrong depths; got %v", depths)
+		}
+	})
+}
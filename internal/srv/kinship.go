@@ -0,0 +1,70 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+// Kinship is how one person is related to another, in a KinshipMatrix.
+type Kinship struct {
+	// Description is like "1st cousin". It's empty if the people are not
+	// related.
+	Description string
+	// Coefficient is the coefficient of relationship.
+	Coefficient float64
+}
+
+// KinshipMatrix relates each pair of the people at ids with the relator. The
+// value at row i and column j is how the person at ids[i] is related to the
+// person at ids[j]. Each pair is only related once, because the relator
+// describes both directions. The relatives of each person are found in one
+// pass with RelateAll. The coefficients only consider birth parents.
+func (g *Graph) KinshipMatrix(ctx context.Context, relator Relator, ids []string) (out [][]Kinship, err error) {
+	for _, id := range ids {
+		if _, ok := g.Person(id); !ok {
+			err = fmt.Errorf("person with id %v not found", id)
+			return
+		}
+	}
+
+	calc := newCoefficients(g)
+	out = make([][]Kinship, len(ids))
+	for i := range ids {
+		out[i] = make([]Kinship, len(ids))
+	}
+
+	for i, id1 := range ids {
+		self, rerr := relator.Relate(ctx, id1, id1)
+		if rerr != nil {
+			err = fmt.Errorf("relating %v with %v: %w", id1, id1, rerr)
+			return
+		}
+		coefficient := calc.relationship(id1, id1)
+		out[i][i] = Kinship{Description: self.R1.Description, Coefficient: coefficient}
+		if i == len(ids)-1 {
+			break
+		}
+
+		relationships, rerr := relator.RelateAll(ctx, id1)
+		if rerr != nil {
+			err = fmt.Errorf("relating %v: %w", id1, rerr)
+			return
+		}
+		relatives := make(map[string]entity.MutualRelationship, len(relationships))
+		for _, m := range relationships {
+			relatives[m.R1.TargetID] = m
+		}
+
+		for j := i + 1; j < len(ids); j++ {
+			id2 := ids[j]
+			m := relatives[id2]
+			coefficient = calc.relationship(id1, id2)
+			out[i][j] = Kinship{Description: m.R1.Description, Coefficient: coefficient}
+			out[j][i] = Kinship{Description: m.R2.Description, Coefficient: coefficient}
+		}
+	}
+
+	return
+}
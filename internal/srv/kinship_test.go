@@ -0,0 +1,58 @@
+package srv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+func TestKinshipMatrix(t *testing.T) {
+	// The parents @I1@ and @I2@ have the children @I3@ and @I4@. Then @I3@ has
+	// a child @I5@. The person @I6@ is not related to anyone.
+	father := &entity.Person{ID: "@I1@", Sex: entity.Male}
+	mother := &entity.Person{ID: "@I2@", Sex: entity.Female}
+	son := &entity.Person{ID: "@I3@", Sex: entity.Male, Parents: []*entity.Person{father, mother}}
+	daughter := &entity.Person{ID: "@I4@", Sex: entity.Female, Parents: []*entity.Person{father, mother}}
+	grandchild := &entity.Person{ID: "@I5@", Parents: []*entity.Person{son}}
+	stranger := &entity.Person{ID: "@I6@"}
+	graph := NewGraph([]*entity.Person{father, mother, son, daughter, grandchild, stranger}, nil)
+
+	ids := []string{father.ID, son.ID, daughter.ID, grandchild.ID, stranger.ID}
+	errorsBefore := errorLogs.Load()
+	got, err := graph.KinshipMatrix(context.Background(), graph.Relator(), ids)
+	if err != nil {
+		t.Fatalf("expected empty error, got %v", err)
+	}
+	if n := errorLogs.Load() - errorsBefore; n != 0 {
+		t.Errorf("unrelated people should not be an error; got %d messages at the ERROR level", n)
+	}
+
+	exp := [][]Kinship{
+		{{"self", 1}, {"father", 0.5}, {"father", 0.5}, {"grandfather", 0.25}, {"", 0}},
+		{{"son", 0.5}, {"self", 1}, {"brother", 0.5}, {"father", 0.5}, {"", 0}},
+		{{"daughter", 0.5}, {"sister", 0.5}, {"self", 1}, {"aunt", 0.25}, {"", 0}},
+		{{"grand child", 0.25}, {"child", 0.5}, {"niece/nephew", 0.25}, {"self", 1}, {"", 0}},
+		{{"", 0}, {"", 0}, {"", 0}, {"", 0}, {"self", 1}},
+	}
+	if len(got) != len(exp) {
+		t.Fatalf("wrong number of rows; got %d, expected %d", len(got), len(exp))
+	}
+	for i, row := range got {
+		if len(row) != len(exp[i]) {
+			t.Errorf("row %d; wrong number of columns; got %d, expected %d", i, len(row), len(exp[i]))
+			continue
+		}
+		for j, cell := range row {
+			if cell != exp[i][j] {
+				t.Errorf("[%d][%d]; wrong value; got %+v, expected %+v", i, j, cell, exp[i][j])
+			}
+		}
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := graph.KinshipMatrix(context.Background(), graph.Relator(), []string{father.ID, "@I999@"}); err == nil {
+			t.Error("expected non-empty error")
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package srv
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/log"
+)
+
+// LivingParams are the assumptions for inferring if a person is living. Each
+// value is a number of years. A zero value is replaced with the value from
+// DefaultLivingParams.
+type LivingParams struct {
+	// MaxAge is the oldest age of a living person. Anyone who would be older
+	// than this is deceased.
+	MaxAge int
+	// MinParentAge is the youngest age of a parent at the birth of a child.
+	MinParentAge int
+}
+
+// DefaultLivingParams are some common assumptions for privacy features.
+var DefaultLivingParams = LivingParams{
+	MaxAge:       110,
+	MinParentAge: DefaultEstimateParams.MinGeneration,
+}
+
+func (p LivingParams) withDefaults() LivingParams {
+	if p.MaxAge == 0 {
+		p.MaxAge = DefaultLivingParams.MaxAge
+	}
+	if p.MinParentAge == 0 {
+		p.MinParentAge = DefaultLivingParams.MinParentAge
+	}
+	return p
+}
+
+// deceasedEventTypes are the types of events that only happen after death.
+var deceasedEventTypes = []string{"Death", "Burial"}
+
+// isDeceasedEvent tells if the event only happens after death.
+func isDeceasedEvent(event *entity.Event) bool {
+	for _, typ := range deceasedEventTypes {
+		if strings.EqualFold(event.Type, typ) {
+			return true
+		}
+	}
+	return false
+}
+
+// InferLiving sets the LivingStatus of each person. A person is deceased if
+// there is any death or burial event, even without a date, or if they would be
+// older than params.MaxAge by now. They're presumed to be living if they were
+// born within params.MaxAge years. Otherwise, it's unknown.
+//
+// When the birth date is unknown, it's bounded by the dates of the person's
+// other events, the births of their parents and children, the deaths of their
+// parents and the EstimatedBirthdate, if any.
+func InferLiving(ctx context.Context, people []*entity.Person, params LivingParams) {
+	params = params.withDefaults()
+	peopleByID := make(map[string]*entity.Person, len(people))
+	for _, person := range people {
+		peopleByID[person.ID] = person
+	}
+	thisYear := time.Now().Year()
+
+	lookup := func(person *entity.Person) *entity.Person {
+		if full, ok := peopleByID[person.ID]; ok {
+			return full
+		}
+		return person
+	}
+
+	for _, person := range people {
+		person.LivingStatus = inferLiving(person, lookup, params, thisYear)
+		log.Debug(ctx, map[string]any{"person_id": person.ID, "living_status": person.LivingStatus}, "inferred living status")
+	}
+}
+
+func inferLiving(person *entity.Person, lookup func(*entity.Person) *entity.Person, params LivingParams, thisYear int) entity.LivingStatus {
+	if person.Deathdate != nil {
+		return entity.Deceased
+	}
+	for _, event := range person.Events {
+		if isDeceasedEvent(event) {
+			return entity.Deceased
+		}
+	}
+
+	// The birth is somewhere between these years.
+	var birth yearConstraints
+	for _, d := range []*entity.Date{person.Birthdate, person.EstimatedBirthdate} {
+		if lo, hi, hasLo, hasHi := d.Years(); hasLo || hasHi {
+			if hasLo {
+				birth.addLo(lo)
+			}
+			if hasHi {
+				birth.addHi(hi)
+			}
+		}
+	}
+	for _, event := range person.Events {
+		if _, hi, _, hasHi := event.Date.Years(); hasHi {
+			birth.addHi(hi)
+		}
+	}
+	for _, parent := range person.Parents {
+		parent = lookup(parent)
+		if lo, _, hasLo, _ := parent.Birthdate.Years(); hasLo {
+			birth.addLo(lo + params.MinParentAge)
+		}
+		if _, hi, _, hasHi := parent.Deathdate.Years(); hasHi {
+			// A father might die before the birth of his child.
+			birth.addHi(hi + 1)
+		}
+	}
+	for _, child := range person.Children {
+		if _, hi, _, hasHi := lookup(child).Birthdate.Years(); hasHi {
+			birth.addHi(hi - params.MinParentAge)
+		}
+	}
+
+	if birth.hasHi && thisYear-birth.hi > params.MaxAge {
+		return entity.Deceased
+	}
+	if birth.hasLo && thisYear-birth.lo <= params.MaxAge {
+		return entity.Living
+	}
+	return entity.LivingUnknown
+}
@@ -0,0 +1,132 @@
+package srv
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+func TestInferLiving(t *testing.T) {
+	thisYear := time.Now().Year()
+	yearsAgo := func(t *testing.T, n int) *entity.Date {
+		t.Helper()
+		return MustParseDate(t, strconv.Itoa(thisYear-n))
+	}
+
+	oldParent := &entity.Person{ID: "@IOldParent@", Birthdate: yearsAgo(t, 200)}
+	youngParent := &entity.Person{ID: "@IYoungParent@", Birthdate: yearsAgo(t, 60)}
+	oldChild := &entity.Person{ID: "@IOldChild@", Birthdate: yearsAgo(t, 100)}
+
+	tests := []struct {
+		Name   string
+		Person *entity.Person
+		Params LivingParams
+		Exp    entity.LivingStatus
+	}{
+		{
+			Name:   "death date",
+			Person: &entity.Person{ID: "@I1@", Birthdate: yearsAgo(t, 30), Deathdate: yearsAgo(t, 1)},
+			Exp:    entity.Deceased,
+		},
+		{
+			Name:   "death event without date",
+			Person: &entity.Person{ID: "@I2@", Birthdate: yearsAgo(t, 30), Events: []*entity.Event{{Type: "Death"}}},
+			Exp:    entity.Deceased,
+		},
+		{
+			Name:   "burial",
+			Person: &entity.Person{ID: "@I3@", Events: []*entity.Event{{Type: "Burial"}}},
+			Exp:    entity.Deceased,
+		},
+		{
+			Name:   "older than max age",
+			Person: &entity.Person{ID: "@I4@", Birthdate: yearsAgo(t, 111)},
+			Exp:    entity.Deceased,
+		},
+		{
+			Name:   "younger than max age",
+			Person: &entity.Person{ID: "@I5@", Birthdate: yearsAgo(t, 109)},
+			Exp:    entity.Living,
+		},
+		{
+			Name:   "configurable max age",
+			Person: &entity.Person{ID: "@I6@", Birthdate: yearsAgo(t, 101)},
+			Params: LivingParams{MaxAge: 100},
+			Exp:    entity.Deceased,
+		},
+		{
+			Name:   "birth range spans max age",
+			Person: &entity.Person{ID: "@I7@", Birthdate: MustParseDate(t, "BET "+strconv.Itoa(thisYear-150)+" AND "+strconv.Itoa(thisYear-50))},
+			Exp:    entity.LivingUnknown,
+		},
+		{
+			Name:   "old event",
+			Person: &entity.Person{ID: "@I8@", Events: []*entity.Event{{Type: "Residence", Date: yearsAgo(t, 120)}}},
+			Exp:    entity.Deceased,
+		},
+		{
+			Name:   "old child",
+			Person: &entity.Person{ID: "@I9@", Children: []*entity.Person{{ID: oldChild.ID}}},
+			Exp:    entity.Deceased,
+		},
+		{
+			Name:   "young parent",
+			Person: &entity.Person{ID: "@I10@", Parents: []*entity.Person{{ID: youngParent.ID}}},
+			Exp:    entity.Living,
+		},
+		{
+			Name:   "old parent",
+			Person: &entity.Person{ID: "@I11@", Parents: []*entity.Person{{ID: oldParent.ID}}},
+			Exp:    entity.LivingUnknown,
+		},
+		{
+			Name:   "estimated birth",
+			Person: &entity.Person{ID: "@I12@", EstimatedBirthdate: yearsAgo(t, 150)},
+			Exp:    entity.Deceased,
+		},
+		{
+			Name:   "nothing known",
+			Person: &entity.Person{ID: "@I13@"},
+			Exp:    entity.LivingUnknown,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			people := []*entity.Person{oldParent, youngParent, oldChild, test.Person}
+			InferLiving(context.Background(), people, test.Params)
+
+			if got := test.Person.LivingStatus; got != test.Exp {
+				t.Errorf("wrong LivingStatus; got %q, expected %q", got, test.Exp)
+			}
+			if got := test.Person.IsLiving(); got != (test.Exp != entity.Deceased) {
+				t.Errorf("wrong IsLiving; got %t", got)
+			}
+		})
+	}
+}
+
+func TestInferLivingInRelationships(t *testing.T) {
+	// The relationships are found after inferring the LivingStatus, so the
+	// people along the paths should have it too.
+	parent := &entity.Person{ID: "@I1@", Deathdate: MustParseDate(t, "1990")}
+	child := &entity.Person{ID: "@I2@", Birthdate: MustParseDate(t, strconv.Itoa(time.Now().Year()-30)), Parents: []*entity.Person{parent}}
+	people := []*entity.Person{parent, child}
+	InferLiving(context.Background(), people, LivingParams{})
+
+	got, err := NewRelator(people).Relate(context.Background(), child.ID, parent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]entity.LivingStatus{parent.ID: entity.Deceased, child.ID: entity.Living}
+	for _, path := range [][]entity.Person{got.R1.Path, got.R2.Path} {
+		for _, person := range path {
+			if person.LivingStatus != exp[person.ID] {
+				t.Errorf("wrong LivingStatus for %s; got %q, expected %q", person.ID, person.LivingStatus, exp[person.ID])
+			}
+		}
+	}
+}
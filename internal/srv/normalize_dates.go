@@ -0,0 +1,104 @@
+package srv
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strconv"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+	"github.com/rafaelespinoza/ged/internal/gedcom"
+)
+
+// DateNormalization is a suggestion to replace a DATE value that is not in the
+// canonical GEDCOM7 format.
+type DateNormalization struct {
+	// RecordXref is the Xref of the top-level record that has the DATE.
+	RecordXref string
+	// Path is where the DATE is within the record, such as "INDI.BIRT.DATE".
+	Path string
+	// Original is the DATE payload, as is.
+	Original string
+	// Replacement is the canonical version of the DATE payload.
+	Replacement string
+	// Phrase is a suggested value for the PHRASE substructure of the DATE. It's
+	// for information that does not fit into the Replacement, such as the text
+	// of a date that could not be interpreted. It's empty if there is nothing
+	// to add.
+	Phrase string
+	// Ambiguous is true if the DATE payload could mean more than one thing,
+	// such as "1731/1732", which is either the interval "FROM 1731 TO 1732" or
+	// the dual year "1731/32". There's no Replacement then, it's up to a person
+	// to decide.
+	Ambiguous bool
+}
+
+// consecutiveYears is an EDTF interval of two consecutive years, which is
+// also how some vendors write a dual year.
+var consecutiveYears = regexp.MustCompile(`^(\d{4})/(\d{4})$`)
+
+// NormalizeDates reads every DATE in the input document r, and suggests a
+// replacement for each one that's not in the canonical GEDCOM7 format. Those
+// are things like "Abt. March 3rd 1890" and "bef 1800", which should be
+// "ABT 3 MAR 1890" and "BEF 1800". The DATE payloads are interpreted with opts.
+func NormalizeDates(ctx context.Context, r io.Reader, opts date.ParseOptions) ([]*DateNormalization, error) {
+	lines, err := gedcom.ReadDateLines(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*DateNormalization, 0, len(lines))
+	for _, line := range lines {
+		if norm := normalizeDate(line, opts); norm != nil {
+			out = append(out, norm)
+		}
+	}
+	return out, nil
+}
+
+// normalizeDate suggests a replacement for the DATE line. The output is nil if
+// the DATE is already canonical.
+func normalizeDate(line *gedcom.DateLine, opts date.ParseOptions) *DateNormalization {
+	out := DateNormalization{
+		RecordXref: line.RecordXref,
+		Path:       line.Path,
+		Original:   line.Payload,
+	}
+
+	if m := consecutiveYears.FindStringSubmatch(line.Payload); m != nil {
+		start, _ := strconv.Atoi(m[1])
+		end, _ := strconv.Atoi(m[2])
+		if end == start+1 {
+			out.Ambiguous = true
+			return &out
+		}
+	}
+
+	dat, rng, err := date.ParseWithOptions(line.Payload, opts)
+	switch {
+	case err != nil:
+		// Keep the text, it's just not a date that a machine could understand.
+		if line.Payload == "" {
+			return nil
+		}
+		out.Phrase = line.Payload
+	case dat != nil:
+		out.Replacement = dat.Format()
+		if dat.Phrase != "" {
+			out.Phrase = dat.Phrase
+		} else if dat.DualYear != 0 {
+			out.Phrase = line.Payload
+		}
+	case rng != nil:
+		out.Replacement = rng.Format()
+	}
+
+	if out.Replacement == out.Original {
+		return nil
+	}
+	if line.Phrase != "" {
+		// The existing PHRASE is more authoritative.
+		out.Phrase = ""
+	}
+	return &out
+}
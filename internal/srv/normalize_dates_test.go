@@ -0,0 +1,70 @@
+package srv
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity/date"
+)
+
+func TestNormalizeDates(t *testing.T) {
+	data := strings.NewReader(`0 HEAD
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME Ada /Lovelace/
+1 BIRT
+2 DATE Abt. March 3rd 1890
+1 RESI
+2 DATE bef 1900
+1 EVEN
+2 DATE 1 JAN 1900
+2 TYPE already canonical
+1 NATU
+2 DATE from 1900 to 1910
+1 DEAT
+2 DATE unknown
+1 BURI
+2 DATE INT 1950 (after the war)
+0 @F1@ FAM
+1 HUSB @I1@
+1 MARR
+2 DATE 11 FEB 1731/32
+1 DIV
+2 DATE (sometime later)
+3 PHRASE sometime later
+1 RESI
+2 DATE 1731/1732
+1 EVEN
+2 DATE 1731/1740
+2 TYPE interval
+0 TRLR
+`)
+
+	got, err := NormalizeDates(context.Background(), data, date.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []DateNormalization{
+		{RecordXref: "@I1@", Path: "INDI.BIRT.DATE", Original: "Abt. March 3rd 1890", Replacement: "ABT 3 MAR 1890"},
+		{RecordXref: "@I1@", Path: "INDI.RESI.DATE", Original: "bef 1900", Replacement: "BEF 1900"},
+		{RecordXref: "@I1@", Path: "INDI.NATU.DATE", Original: "from 1900 to 1910", Replacement: "FROM 1900 TO 1910"},
+		{RecordXref: "@I1@", Path: "INDI.DEAT.DATE", Original: "unknown", Replacement: "", Phrase: "unknown"},
+		{RecordXref: "@I1@", Path: "INDI.BURI.DATE", Original: "INT 1950 (after the war)", Replacement: "1950", Phrase: "after the war"},
+		{RecordXref: "@F1@", Path: "FAM.MARR.DATE", Original: "11 FEB 1731/32", Replacement: "11 FEB 1732", Phrase: "11 FEB 1731/32"},
+		{RecordXref: "@F1@", Path: "FAM.DIV.DATE", Original: "(sometime later)", Replacement: ""},
+		{RecordXref: "@F1@", Path: "FAM.RESI.DATE", Original: "1731/1732", Ambiguous: true},
+		{RecordXref: "@F1@", Path: "FAM.EVEN.DATE", Original: "1731/1740", Replacement: "FROM 1731 TO 1740"},
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("wrong number of results; got %d, exp %d", len(got), len(expected))
+	}
+
+	for i, exp := range expected {
+		if *got[i] != exp {
+			t.Errorf("item[%d]; got %+v, exp %+v", i, *got[i], exp)
+		}
+	}
+}
@@ -7,16 +7,23 @@ import (
 	"slices"
 
 	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/entity/date"
 	"github.com/rafaelespinoza/ged/internal/gedcom"
 	"github.com/rafaelespinoza/ged/internal/log"
 )
 
-func ParseGedcom(ctx context.Context, r io.Reader) ([]*entity.Person, []*entity.Union, error) {
-	records, err := gedcom.ReadRecords(ctx, r)
+func ParseGedcom(ctx context.Context, r io.Reader, opts date.ParseOptions) ([]*entity.Person, []*entity.Union, error) {
+	records, err := gedcom.ReadRecords(ctx, r, opts)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	return ConvertRecords(ctx, records)
+}
+
+// ConvertRecords is like ParseGedcom, but for GEDCOM records that have already
+// been read.
+func ConvertRecords(ctx context.Context, records *gedcom.Records) ([]*entity.Person, []*entity.Union, error) {
 	log.Info(ctx, map[string]any{"records": records}, "converted gedcom records")
 
 	gedcomFamiliesByID := make(map[string]*gedcom.FamilyRecord, len(records.Families))
@@ -84,6 +91,17 @@ func convertGedcomPeople(ctx context.Context, records []*gedcom.IndividualRecord
 			}
 		}
 
+		events, err := convertGedcomEvents(individual.EventLog())
+		if err != nil {
+			log.Error(ctx, map[string]any{"individual": individual}, err, "invalid Events")
+			return nil, err
+		}
+		attributes, err := convertGedcomAttributes(individual.Attributes)
+		if err != nil {
+			log.Error(ctx, map[string]any{"individual": individual}, err, "invalid Attributes")
+			return nil, err
+		}
+
 		out[individual.Xref] = &entity.Person{
 			ID: individual.Xref,
 			Name: entity.PersonalName{
@@ -92,12 +110,22 @@ func convertGedcomPeople(ctx context.Context, records []*gedcom.IndividualRecord
 				Surname:  inputName.Surname,
 				Suffix:   inputName.NameSuffix,
 			},
-			Birthdate: birthdate,
-			Deathdate: deathdate,
+			Sex:        entity.Sex(individual.Sex),
+			Birthdate:  birthdate,
+			Deathdate:  deathdate,
+			Events:     events,
+			Attributes: attributes,
+			Notes:      convertGedcomNotes(individual.Notes),
 		}
 	}
 
 	for _, individual := range records {
+		pedigrees := make(map[string]entity.Pedigree, len(individual.ChildLinks))
+		for _, link := range individual.ChildLinks {
+			pedigrees[link.Xref] = entity.Pedigree(link.Pedigree)
+		}
+
+		var parentPedigrees map[string]entity.Pedigree
 		parentTuples := make([]*entity.Person, 0, len(individual.FamiliesAsChild)*2)
 		for _, famID := range individual.FamiliesAsChild {
 			familyRecord, ok := gedcomFamiliesByID[famID]
@@ -111,6 +139,13 @@ func convertGedcomPeople(ctx context.Context, records []*gedcom.IndividualRecord
 					return nil, fmt.Errorf("entity parent %q from family %q not found for individual as child %q", parentID, famID, individual.Xref)
 				}
 				parentTuples = append(parentTuples, simplifyPerson(parent))
+
+				if pedigree := pedigrees[famID]; !pedigree.IsBirth() {
+					if parentPedigrees == nil {
+						parentPedigrees = make(map[string]entity.Pedigree)
+					}
+					parentPedigrees[parentID] = pedigree
+				}
 			}
 		}
 
@@ -144,6 +179,7 @@ func convertGedcomPeople(ctx context.Context, records []*gedcom.IndividualRecord
 
 		person := out[individual.Xref]
 		person.Parents = slices.Clip(parentTuples)
+		person.ParentPedigrees = parentPedigrees
 		person.Children = slices.Clip(childTuples)
 		person.Spouses = slices.Clip(spouseTuples)
 		out[individual.Xref] = person
@@ -152,12 +188,58 @@ func convertGedcomPeople(ctx context.Context, records []*gedcom.IndividualRecord
 	return out, nil
 }
 
+func convertGedcomEvents(in []*gedcom.Event) (out []*entity.Event, err error) {
+	out = make([]*entity.Event, len(in))
+	for i, event := range in {
+		out[i] = &entity.Event{
+			Type:  event.Type,
+			Place: event.Place,
+			Notes: convertGedcomNotes(event.Notes),
+		}
+		if event.Date != nil || event.DateRange != nil {
+			if out[i].Date, err = entity.NewDate(event.Date, event.DateRange); err != nil {
+				return nil, fmt.Errorf("event[%d] %q: %w", i, event.Type, err)
+			}
+		}
+	}
+	return
+}
+
+func convertGedcomAttributes(in []*gedcom.Attribute) (out []*entity.Attribute, err error) {
+	out = make([]*entity.Attribute, len(in))
+	for i, attribute := range in {
+		out[i] = &entity.Attribute{
+			Type:  attribute.Type,
+			Value: attribute.Value,
+			Place: attribute.Place,
+			Notes: convertGedcomNotes(attribute.Notes),
+		}
+		if attribute.Date != nil || attribute.DateRange != nil {
+			if out[i].Date, err = entity.NewDate(attribute.Date, attribute.DateRange); err != nil {
+				return nil, fmt.Errorf("attribute[%d] %q: %w", i, attribute.Type, err)
+			}
+		}
+	}
+	return
+}
+
+func convertGedcomNotes(in []*gedcom.Note) (out []string) {
+	if len(in) < 1 {
+		return
+	}
+	out = make([]string, len(in))
+	for i, note := range in {
+		out[i] = note.Payload
+	}
+	return
+}
+
 func convertGedcomFamilies(ctx context.Context, records []*gedcom.FamilyRecord, peopleByGCID map[string]*entity.Person) (map[string]*entity.Union, error) {
 	out := make(map[string]*entity.Union, len(records))
 
 	var err error
 
-	for i, family := range records {
+	for _, family := range records {
 		union := entity.Union{ID: family.Xref}
 		if family.MarriedAt != nil {
 			union.StartDate, err = entity.NewDate(family.MarriedAt.Date, family.MarriedAt.DateRange)
@@ -181,32 +263,19 @@ func convertGedcomFamilies(ctx context.Context, records []*gedcom.FamilyRecord,
 			}
 		}
 
-		partners := make([]*entity.Person, len(family.ParentXrefs))
-		for i, partnerID := range family.ParentXrefs {
-			partner, ok := peopleByGCID[partnerID]
+		if len(family.Partners) < 1 {
+			return nil, fmt.Errorf("no partner references for family %q", family.Xref)
+		}
+		union.Partners = make([]*entity.Partner, len(family.Partners))
+		for i, familyPartner := range family.Partners {
+			partner, ok := peopleByGCID[familyPartner.Xref]
 			if !ok {
-				return nil, fmt.Errorf("partner %q not found for family %q", partnerID, family.Xref)
+				return nil, fmt.Errorf("partner %q not found for family %q", familyPartner.Xref, family.Xref)
+			}
+			union.Partners[i] = &entity.Partner{
+				Person: simplifyPerson(partner),
+				Role:   entity.PartnerRole(familyPartner.Role),
 			}
-			partners[i] = simplifyPerson(partner)
-		}
-
-		switch len(partners) {
-		case 0:
-			return nil, fmt.Errorf("no partner references for family %q", family.Xref)
-		case 1:
-			union.Person1 = partners[0]
-		case 2:
-			union.Person1 = partners[0]
-			union.Person2 = partners[1]
-		default:
-			union.Person1 = partners[0]
-			union.Person2 = partners[1]
-
-			log.Warn(ctx, map[string]any{
-				"xref":        family.Xref,
-				"i":           i,
-				"partner_ids": family.ParentXrefs[2:],
-			}, "discarding extra partner references in family")
 		}
 
 		children := make([]*entity.Person, len(family.ChildXrefs))
@@ -228,11 +297,15 @@ func convertGedcomFamilies(ctx context.Context, records []*gedcom.FamilyRecord,
 // simplifyPerson intentionally does not copy the Children, Parent, or Spouses
 // fields to help keep each output item succinct. This is most beneficial when
 // marshaling the results. Without such a limit, you could end up with
-// generations upon generations of deeply-nested structures.
+// generations upon generations of deeply-nested structures. For the same
+// reason, the Events, Attributes and Notes are not copied either. Fields that
+// are derived later, such as the EstimatedBirthdate, are not known yet, so
+// look up the person by ID for those.
 func simplifyPerson(in *entity.Person) *entity.Person {
 	return &entity.Person{
 		ID:        in.ID,
 		Name:      in.Name,
+		Sex:       in.Sex,
 		Birthdate: in.Birthdate,
 		Deathdate: in.Deathdate,
 	}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/entity/date"
 )
 
 func TestParseGedcom(t *testing.T) {
@@ -17,7 +22,7 @@ func TestParseGedcom(t *testing.T) {
 		}
 		defer func() { _ = file.Close() }()
 
-		people, unions, err := ParseGedcom(context.Background(), file)
+		people, unions, err := ParseGedcom(context.Background(), file, date.ParseOptions{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -51,11 +56,13 @@ func TestParseGedcom(t *testing.T) {
 				t.Error("empty ID for union")
 			}
 
-			if union.Person1 != nil && union.Person1.ID == "" {
-				t.Error("person 1 in union has empty ID")
+			if len(union.Partners) < 1 {
+				t.Errorf("no partners in union %q", union.ID)
 			}
-			if union.Person2 != nil && union.Person2.ID == "" {
-				t.Error("person 2 in union has empty ID")
+			for i, partner := range union.Partners {
+				if partner.Person == nil || partner.Person.ID == "" {
+					t.Errorf("partner %d in union %q has empty ID", i, union.ID)
+				}
 			}
 
 			for _, child := range union.Children {
@@ -66,3 +73,116 @@ func TestParseGedcom(t *testing.T) {
 		}
 	}
 }
+
+func TestParseGedcomPersonDetails(t *testing.T) {
+	data := strings.NewReader(`0 HEAD
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME Charlie /Foxtrot/
+1 SEX M
+1 BIRT
+2 DATE 1 JAN 1970
+2 PLAC Springfield
+1 RESI
+2 DATE 1995
+2 PLAC Shelbyville
+2 NOTE Moved for work.
+1 OCCU Programmer
+2 PLAC Shelbyville
+1 NOTE Likes computers.
+0 TRLR
+`)
+
+	people, _, err := ParseGedcom(context.Background(), data, date.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(people) != 1 {
+		t.Fatalf("wrong number of people; got %d, expected %d", len(people), 1)
+	}
+	got := people[0]
+
+	if got.Sex != entity.Male {
+		t.Errorf("wrong Sex; got %q, expected %q", got.Sex, entity.Male)
+	}
+
+	expectedEvents := []entity.Event{
+		{Type: "Birth", Place: "Springfield"},
+		{Type: "Residence", Place: "Shelbyville", Notes: []string{"Moved for work."}},
+	}
+	if len(got.Events) != len(expectedEvents) {
+		t.Fatalf("wrong number of Events; got %d, expected %d", len(got.Events), len(expectedEvents))
+	}
+	for i, exp := range expectedEvents {
+		event := got.Events[i]
+		if event.Type != exp.Type {
+			t.Errorf("Events[%d]; wrong Type; got %q, expected %q", i, event.Type, exp.Type)
+		}
+		if event.Place != exp.Place {
+			t.Errorf("Events[%d]; wrong Place; got %q, expected %q", i, event.Place, exp.Place)
+		}
+		if event.Date == nil {
+			t.Errorf("Events[%d]; expected non-empty Date", i)
+		}
+		if !slices.Equal(event.Notes, exp.Notes) {
+			t.Errorf("Events[%d]; wrong Notes; got %q, expected %q", i, event.Notes, exp.Notes)
+		}
+	}
+
+	if len(got.Attributes) != 1 {
+		t.Fatalf("wrong number of Attributes; got %d, expected %d", len(got.Attributes), 1)
+	}
+	if attr := got.Attributes[0]; attr.Type != "Occupation" || attr.Value != "Programmer" || attr.Place != "Shelbyville" {
+		t.Errorf("wrong Attribute; got %+v", attr)
+	}
+
+	if !slices.Equal(got.Notes, []string{"Likes computers."}) {
+		t.Errorf("wrong Notes; got %q", got.Notes)
+	}
+}
+
+func TestParseGedcomUnionPartners(t *testing.T) {
+	data := strings.NewReader(`0 HEAD
+1 CHAR UTF-8
+0 @I1@ INDI
+1 NAME Alpha /Foo/
+1 FAMS @F1@
+0 @I2@ INDI
+1 NAME Bravo /Foo/
+1 FAMS @F1@
+0 @I3@ INDI
+1 NAME Charlie /Foo/
+1 FAMS @F1@
+0 @F1@ FAM
+1 HUSB @I1@
+1 WIFE @I2@
+1 HUSB @I3@
+0 TRLR
+`)
+
+	_, unions, err := ParseGedcom(context.Background(), data, date.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unions) != 1 {
+		t.Fatalf("wrong number of unions; got %d, expected %d", len(unions), 1)
+	}
+
+	expected := []entity.Partner{
+		{Person: &entity.Person{ID: "@I1@"}, Role: entity.Husband},
+		{Person: &entity.Person{ID: "@I2@"}, Role: entity.Wife},
+		{Person: &entity.Person{ID: "@I3@"}, Role: entity.Husband},
+	}
+	got := unions[0].Partners
+	if len(got) != len(expected) {
+		t.Fatalf("wrong number of Partners; got %d, expected %d", len(got), len(expected))
+	}
+	for i, exp := range expected {
+		if got[i].Person.ID != exp.Person.ID {
+			t.Errorf("Partners[%d]; wrong ID; got %q, expected %q", i, got[i].Person.ID, exp.Person.ID)
+		}
+		if got[i].Role != exp.Role {
+			t.Errorf("Partners[%d]; wrong Role; got %q, expected %q", i, got[i].Role, exp.Role)
+		}
+	}
+}
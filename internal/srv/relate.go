@@ -12,45 +12,84 @@ import (
 
 type Relator interface {
 	Relate(ctx context.Context, person1ID, person2ID string) (out entity.MutualRelationship, err error)
+	// RelateEveryWay finds every distinct relationship between the people, such
+	// as double first cousins, or cousins who are also in-laws. The closest
+	// relationships are first.
+	RelateEveryWay(ctx context.Context, person1ID, person2ID string) (out []entity.MutualRelationship, err error)
+	// RelateAll relates one person with each other person that they are
+	// related to, closest first. It's much faster than calling Relate for each
+	// of them.
+	RelateAll(ctx context.Context, homeID string) (out []entity.MutualRelationship, err error)
 }
 
+// NewRelator indexes the people, then relates them. If there's already a
+// Graph, then use its Relator method instead.
 func NewRelator(people []*entity.Person) Relator {
-	out := relator{
-		peopleByID:       make(map[string]*entity.Person, len(people)),
-		childToParentIDs: make(map[string]idSet, len(people)),
-		spousePartners:   make(map[string]idSet, len(people)),
-	}
+	return NewGraph(people, nil).Relator()
+}
 
-	for _, person := range people {
-		out.peopleByID[person.ID] = person
-		childParentIDs := make(idSet)
-		spousePartners := make(idSet)
+// Relator relates the people in the Graph.
+func (g *Graph) Relator() Relator { return &relator{graph: g} }
 
-		for _, parent := range person.Parents {
-			childParentIDs.add(parent.ID)
-		}
+// GeneticRelator only finds blood relationships, and only through parents and
+// children who are related by birth. So it ignores adoptive, foster, step
+// and affinal relationships, which is what matters for DNA.
+func (g *Graph) GeneticRelator() Relator { return &relator{graph: g, genetic: true} }
 
-		for _, spouse := range person.Spouses {
-			spousePartners.add(spouse.ID)
-		}
+// XRelator is like GeneticRelator, but it only finds the relationships along
+// which X-DNA could be inherited. A son only gets an X chromosome from his
+// mother, while a daughter gets one from each parent. So a line may only go
+// from a child to their father if the child is female.
+func (g *Graph) XRelator() Relator { return &relator{graph: g, genetic: true, xDNA: true} }
 
-		out.childToParentIDs[person.ID] = childParentIDs
-		out.spousePartners[person.ID] = spousePartners
+type relator struct {
+	graph   *Graph
+	genetic bool
+	xDNA    bool
+}
+
+// parentIDs are the IDs of the parents of the person at id. If the relator is
+// genetic, then it's only the birth parents.
+func (r *relator) parentIDs(id string) []string {
+	if !r.genetic {
+		return r.graph.parentIDs[id]
 	}
 
-	return &out
+	var out []string
+	for _, parentID := range r.graph.parentIDs[id] {
+		if r.graph.Pedigree(id, parentID).IsBirth() {
+			out = append(out, parentID)
+		}
+	}
+	return out
 }
 
-type relator struct {
-	peopleByID       map[string]*entity.Person
-	childToParentIDs map[string]idSet
-	spousePartners   map[string]idSet // key is ID, value is set of IDs for spouses
+// lineParentIDs are the IDs of the parents of the person at id, which an
+// ancestral line may go through. Unlike parentIDs, it's limited by the rules
+// of X-DNA inheritance if the relator is for X-DNA.
+func (r *relator) lineParentIDs(id string) []string {
+	parentIDs := r.parentIDs(id)
+	if !r.xDNA || r.sexOf(id) == entity.Female {
+		return parentIDs
+	}
+
+	var out []string
+	for _, parentID := range parentIDs {
+		if r.sexOf(parentID) == entity.Female {
+			out = append(out, parentID)
+		}
+	}
+	return out
 }
 
 const maxGenerationsToRelate = 100
 
 var errUnrelated = errors.New("it appears that these people are unrelated")
 
+// IsUnrelated tells if an error from a Relator is because the people are not
+// related.
+func IsUnrelated(err error) bool { return errors.Is(err, errUnrelated) }
+
 func (r *relator) Relate(ctx context.Context, p1ID, p2ID string) (out entity.MutualRelationship, err error) {
 	_, ok := r.lookupOne(p1ID)
 	if !ok {
@@ -64,19 +103,30 @@ func (r *relator) Relate(ctx context.Context, p1ID, p2ID string) (out entity.Mut
 	}
 
 	r1, r2, ancestor, err := r.relate(ctx, p1ID, p2ID)
-	if errors.Is(err, errUnrelated) {
+	if errors.Is(err, errUnrelated) && r.genetic {
+		return
+	} else if errors.Is(err, errUnrelated) {
 		// now see if they are related via marriage
 	} else if err != nil {
 		return
 	} else {
 		out = entity.MutualRelationship{
-			CommonPerson: ancestor,
-			R1:           r1,
-			R2:           r2,
+			CommonPerson:    ancestor,
+			CommonAncestors: r.ancestralCouple(pathIDs(r1.Path), pathIDs(r2.Path)),
+			R1:              r1,
+			R2:              r2,
 		}
 		return
 	}
 
+	// A step relationship goes first. Otherwise, a step-child would be an
+	// in-law, through the union of their parent.
+	if steps := r.relateSteps(p1ID, p2ID); len(steps) > 0 {
+		out = steps[0].MutualRelationship
+		err = nil
+		return
+	}
+
 	r1, r2, union, err := r.affiniate(ctx, p1ID, p2ID)
 	if err != nil {
 		return
@@ -118,7 +168,7 @@ func (r *relator) relate(ctx context.Context, p1ID, p2ID string) (r1, r2 entity.
 
 	ancestorID, shortestP1Path, shortestP2Path := getShortestCommonPaths(ctx, r, p1Paths, p2Paths)
 
-	ancestor, ok := r.peopleByID[ancestorID]
+	ancestor, ok := r.graph.Person(ancestorID)
 	if !ok {
 		err = errUnrelated
 		log.Error(ctx, map[string]any{"p1": p1ID, "p2": p2ID}, err, "")
@@ -137,8 +187,15 @@ func (r *relator) relate(ctx context.Context, p1ID, p2ID string) (r1, r2 entity.
 }
 
 func (r *relator) lookupOne(id string) (out *entity.Person, found bool) {
-	out, found = r.peopleByID[id]
-	return
+	return r.graph.Person(id)
+}
+
+// sexOf the person at id is used to choose the terms that describe them.
+func (r *relator) sexOf(id string) entity.Sex {
+	if person, ok := r.lookupOne(id); ok {
+		return person.Sex
+	}
+	return entity.SexUnknown
 }
 
 func findCommonAncestorPaths(ctx context.Context, tag string, r *relator, currGeneration int, visited idSet, allPaths pathsToPersonID, prevPath []string, id string) {
@@ -154,7 +211,7 @@ func findCommonAncestorPaths(ctx context.Context, tag string, r *relator, currGe
 		// return
 	}
 
-	parentIDs := r.childToParentIDs[id].ids() // should be non-nil b/c of how the map was built earlier.
+	parentIDs := r.lineParentIDs(id)
 
 	for _, parentID := range parentIDs {
 		findCommonAncestorPaths(ctx, tag, r, currGeneration+1, visited, allPaths, currPath, parentID)
@@ -241,23 +298,25 @@ func (s idSet) has(id string) (ok bool) {
 	return
 }
 
-func (s idSet) ids() []string {
+// sorted are the IDs in the set, sorted for deterministic results.
+func (s idSet) sorted() []string {
 	out := make([]string, 0, len(s))
 	for id := range s {
 		out = append(out, id)
 	}
+	slices.Sort(out)
 	return out
 }
 
 func (r *relator) affiniate(ctx context.Context, p1ID, p2ID string) (r1, r2 entity.Relationship, u *entity.Union, err error) {
 	if spouses(r, p1ID, p2ID) {
 		r1.Type, r2.Type = entity.Spouse, entity.Spouse
-		r1.Description, r2.Description = r1.Type.String(), r2.Type.String()
+		r1.Description, r2.Description = r1.Type.Term(r.sexOf(p1ID)), r2.Type.Term(r.sexOf(p2ID))
 
 		p1, _ := r.lookupOne(p1ID)
 		p2, _ := r.lookupOne(p2ID)
 		r1.Path, r2.Path = []entity.Person{*p1}, []entity.Person{*p2}
-		u = &entity.Union{Person1: p1, Person2: p2}
+		u = entity.NewUnionOf(p1, p2)
 		return
 	}
 
@@ -288,22 +347,20 @@ func (r *relator) affiniate(ctx context.Context, p1ID, p2ID string) (r1, r2 enti
 
 	if m1 != nil && m2 == nil {
 		log.Debug(ctx, map[string]any{
-			"p1_id":               p1ID,
-			"p2_id":               p2ID,
-			"m1.R1.Type":          m1.R1.Type.String(),
-			"m1.R2.Type":          m1.R2.Type.String(),
-			"m1.Union.Person1.ID": m1.Union.Person1.ID,
-			"m1.Union.Person2.ID": m1.Union.Person2.ID,
+			"p1_id":             p1ID,
+			"p2_id":             p2ID,
+			"m1.R1.Type":        m1.R1.Type.String(),
+			"m1.R2.Type":        m1.R2.Type.String(),
+			"m1.Union.Partners": m1.Union.People(),
 		}, "# relator.affiniate: before affiniate")
 		r2, r1, u = affiniate(ctx, r, p1ID, *m1, p2ID)
 	} else if m1 == nil && m2 != nil {
 		log.Debug(ctx, map[string]any{
-			"p1_id":               p1ID,
-			"p2_id":               p2ID,
-			"m2.R1.Type":          m2.R1.Type.String(),
-			"m2.R2.Type":          m2.R2.Type.String(),
-			"m2.Union.Person1.ID": m2.Union.Person1.ID,
-			"m2.Union.Person2.ID": m2.Union.Person2.ID,
+			"p1_id":             p1ID,
+			"p2_id":             p2ID,
+			"m2.R1.Type":        m2.R1.Type.String(),
+			"m2.R2.Type":        m2.R2.Type.String(),
+			"m2.Union.Partners": m2.Union.People(),
 		}, "# relator.affiniate: before affiniate")
 		r1, r2, u = affiniate(ctx, r, p2ID, *m2, p1ID)
 	}
@@ -312,9 +369,13 @@ func (r *relator) affiniate(ctx context.Context, p1ID, p2ID string) (r1, r2 enti
 }
 
 // relateSpouses determines if any spouses for the person at P1ID have a blood
-// relationship with the person at p2.
+// relationship with the person at p2. A spouse who is a parent of p2 is
+// skipped, because that's a step relationship, if any.
 func relateSpouses(ctx context.Context, r *relator, p1ID, p2ID string) (*entity.MutualRelationship, error) {
-	for _, p1SpouseID := range r.spousePartners[p1ID].ids() {
+	for _, p1SpouseID := range r.graph.spouseIDs[p1ID] {
+		if slices.Contains(r.parentIDs(p2ID), p1SpouseID) {
+			continue
+		}
 		r1, r2, p, rerr := r.relate(ctx, p1SpouseID, p2ID)
 		if errors.Is(rerr, errUnrelated) {
 			continue
@@ -336,7 +397,7 @@ func relateSpouses(ctx context.Context, r *relator, p1ID, p2ID string) (*entity.
 
 		p1, _ := r.lookupOne(p1SpouseID)
 		p2, _ := r.lookupOne(p1ID)
-		out.Union = &entity.Union{Person1: p1, Person2: p2}
+		out.Union = entity.NewUnionOf(p1, p2)
 
 		return &out, nil
 	}
@@ -348,10 +409,12 @@ func relateSpouses(ctx context.Context, r *relator, p1ID, p2ID string) (*entity.
 // relationship m between a spouse of the person at pID and the other person at
 // oID.
 func affiniate(ctx context.Context, r *relator, pID string, m entity.MutualRelationship, oID string) (r1, r2 entity.Relationship, u *entity.Union) {
-	r1 = invertRelationship(m.R1)
+	// The inversion of m.R2 describes the spouse, but the affinal
+	// relationship describes the person at pID.
+	r1 = invertRelationship(m.R1, r.sexOf(oID))
 	changeTypeToAffinal(ctx, &r1)
 
-	r2 = invertRelationship(m.R2)
+	r2 = invertRelationship(m.R2, r.sexOf(pID))
 	changeTypeToAffinal(ctx, &r2)
 
 	switch r1.Type {
@@ -369,9 +432,7 @@ func affiniate(ctx context.Context, r *relator, pID string, m entity.MutualRelat
 		if !includesPerson {
 			var indexOfCommonPerson int
 			for i, person := range m.R2.Path { // read from original path, in its pre-inversion state
-				if (m.CommonPerson != nil && person.ID == m.CommonPerson.ID) ||
-					(m.Union.Person1 != nil && person.ID == m.Union.Person1.ID) ||
-					(m.Union.Person2 != nil && person.ID == m.Union.Person2.ID) {
+				if (m.CommonPerson != nil && person.ID == m.CommonPerson.ID) || m.Union.HasPartner(person.ID) {
 					indexOfCommonPerson = i
 					break
 				}
@@ -396,7 +457,9 @@ func affiniate(ctx context.Context, r *relator, pID string, m entity.MutualRelat
 	return
 }
 
-func invertRelationship(in entity.Relationship) (out entity.Relationship) {
+// invertRelationship describes the relationship from the other side, for a
+// person of the given sex.
+func invertRelationship(in entity.Relationship, sex entity.Sex) (out entity.Relationship) {
 	switch in.Type {
 	case entity.Parent:
 		out.Type = entity.Child
@@ -406,35 +469,45 @@ func invertRelationship(in entity.Relationship) (out entity.Relationship) {
 		out.Type = entity.NieceNephew
 	case entity.NieceNephew:
 		out.Type = entity.AuntUncle
+	case entity.HalfAuntUncle:
+		out.Type = entity.HalfNieceNephew
+	case entity.HalfNieceNephew:
+		out.Type = entity.HalfAuntUncle
 	default:
 		out.Type = in.Type
 	}
 
 	out.GenerationsRemoved = in.GenerationsRemoved * -1
+	out.Pedigree = in.Pedigree
 
 	out.Path = make([]entity.Person, len(in.Path))
 	copy(out.Path, in.Path)
 	slices.Reverse(out.Path)
 
 	generationsSinceCommonAncestor := len(out.Path) - 1
-	if in.Type == entity.Cousin {
-		generationsSinceCommonAncestor *= -1
+	if in.Type.Whole() == entity.Cousin {
+		// The path is from the other cousin, who may be a different number of
+		// generations from the common ancestor.
+		generationsSinceCommonAncestor = -(generationsSinceCommonAncestor + out.GenerationsRemoved)
 	}
 
-	desc, _, err := describeRelationship(out.Type, out.GenerationsRemoved, generationsSinceCommonAncestor)
+	desc, _, err := describeRelationship(out.Type, sex, out.GenerationsRemoved, generationsSinceCommonAncestor)
 	if err != nil {
 		log.Error(context.TODO(), map[string]any{
 			"in":  in,
 			"out": out,
 		}, err, "srv.invertRelationship: could not compute lineage inversion")
 	}
-	out.Description = desc
+	out.Description = qualifyDescription(desc, out.Type, out.Pedigree)
 	out.SourceID, out.TargetID = in.TargetID, in.SourceID
 
 	return
 }
 
 func changeTypeToAffinal(ctx context.Context, r *entity.Relationship) {
+	// There are no half-blood affinal types, but the Description keeps the
+	// "half" part, like "half sibling in-law".
+	r.Type = r.Type.Whole()
 	if r.Type >= entity.Spouse {
 		log.Debug(ctx, map[string]any{"type": r.Type.String()}, "# changeTypeToAffinal: Type seems to already be affinal")
 		return
@@ -456,14 +529,5 @@ func spouseIDs(r *relator, id string, people ...entity.Person) (out []string) {
 }
 
 func spouses(r *relator, p1ID, p2ID string) bool {
-	p1Spouses, ok := r.spousePartners[p1ID]
-	if !ok {
-		return false
-	}
-	p2Spouses, ok := r.spousePartners[p2ID]
-	if !ok {
-		return false
-	}
-
-	return p1Spouses.has(p2ID) && p2Spouses.has(p1ID)
+	return r.graph.IsSpouse(p1ID, p2ID)
 }
@@ -0,0 +1,201 @@
+package srv
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+// RelateAll relates the person at homeID with each other person in the Graph,
+// closest first. People who are not related are left out. The ancestral lines
+// of the home person, and of each of their spouses, are only found once, so
+// it's much faster than calling Relate for each person.
+//
+// Each person is related in the same way as Relate would. Blood relationships
+// are first. Then come the step relationships, and the relationships through
+// a marriage of the home person, or of a blood relative.
+func (r *relator) RelateAll(ctx context.Context, homeID string) (out []entity.MutualRelationship, err error) {
+	if _, ok := r.lookupOne(homeID); !ok {
+		err = fmt.Errorf("person with id %v not found", homeID)
+		return
+	}
+
+	homeLines := r.ancestralLines(homeID)
+	spouseLines := make(map[string]map[string][][]string)
+	if !r.genetic {
+		for _, spouseID := range r.graph.spouseIDs[homeID] {
+			spouseLines[spouseID] = r.ancestralLines(spouseID)
+		}
+	}
+
+	// The blood relatives of the home person, and of a spouse of the home
+	// person, keyed by the ID of the relative.
+	blood, bySpouse := make(map[string]rankedRelationship), make(map[string]rankedRelationship)
+	found := make([]rankedRelationship, 0)
+	for _, person := range r.graph.people {
+		if person.ID == homeID {
+			continue
+		}
+
+		lines := r.ancestralLines(person.ID)
+		rel, ok, rerr := r.relateClosestByBlood(homeID, homeLines, person.ID, lines)
+		if rerr != nil {
+			err = fmt.Errorf("relating %v with %v: %w", homeID, person.ID, rerr)
+			return
+		} else if ok {
+			blood[person.ID] = rel
+			found = append(found, rel)
+			continue
+		} else if r.genetic {
+			continue
+		}
+
+		for _, spouseID := range r.graph.spouseIDs[homeID] {
+			if spouseID == person.ID || slices.Contains(r.parentIDs(person.ID), spouseID) {
+				continue // a spouse, or a step-child.
+			}
+			rel, ok, rerr = r.relateClosestByBlood(spouseID, spouseLines[spouseID], person.ID, lines)
+			if rerr != nil {
+				err = fmt.Errorf("relating %v with %v: %w", spouseID, person.ID, rerr)
+				return
+			} else if ok {
+				bySpouse[person.ID] = rel
+				break
+			}
+		}
+	}
+
+	if !r.genetic {
+		stepIDs := r.stepCandidates(homeID)
+		for _, person := range r.graph.people {
+			if person.ID == homeID {
+				continue
+			} else if _, ok := blood[person.ID]; ok {
+				continue
+			}
+
+			if stepIDs.has(person.ID) {
+				if steps := r.relateSteps(homeID, person.ID); len(steps) > 0 {
+					found = append(found, steps[0])
+					continue
+				}
+			}
+			if rel, ok := r.relateByMarriage(ctx, homeID, person.ID, blood, bySpouse); ok {
+				found = append(found, rel)
+			}
+		}
+	}
+
+	slices.SortStableFunc(found, func(a, b rankedRelationship) int {
+		if a.affinal != b.affinal {
+			if a.affinal {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(a.distance, b.distance)
+	})
+
+	out = make([]entity.MutualRelationship, len(found))
+	for i, rel := range found {
+		out[i] = rel.MutualRelationship
+	}
+	return
+}
+
+// relateClosestByBlood is the closest of the relationships from
+// relateLinesByBlood. It's ok if there is any.
+func (r *relator) relateClosestByBlood(p1ID string, lines1 map[string][][]string, p2ID string, lines2 map[string][][]string) (out rankedRelationship, ok bool, err error) {
+	found, err := r.relateLinesByBlood(p1ID, lines1, p2ID, lines2)
+	if err != nil || len(found) < 1 {
+		return
+	}
+
+	out = slices.MinFunc(found, func(a, b rankedRelationship) int { return cmp.Compare(a.distance, b.distance) })
+	ok = true
+	return
+}
+
+// stepCandidates are the people who may have a step relationship with the
+// person at id. Those are the step-parents, the children of a step-parent, and
+// the children of a spouse.
+func (r *relator) stepCandidates(id string) idSet {
+	out := make(idSet)
+	for _, link := range r.stepLinks(id) {
+		out.add(link.stepParentID)
+		for _, childID := range r.graph.childIDs[link.stepParentID] {
+			out.add(childID)
+		}
+	}
+	for _, spouseID := range r.graph.spouseIDs[id] {
+		for _, childID := range r.graph.childIDs[spouseID] {
+			out.add(childID)
+		}
+	}
+	return out
+}
+
+// relateByMarriage relates the person at homeID with the person at id, who is
+// not a blood relative, nor a step relative. The person at id is a spouse of
+// the home person, a blood relative of a spouse, or a spouse of a blood
+// relative. If they are both of the last two, then the closer one is chosen.
+// The blood relationships were already found, keyed by the ID of the relative.
+// Those in blood are with the home person, and those in bySpouse are with a
+// spouse of the home person.
+func (r *relator) relateByMarriage(ctx context.Context, homeID, id string, blood, bySpouse map[string]rankedRelationship) (out rankedRelationship, ok bool) {
+	if spouses(r, homeID, id) {
+		r1, r2, u, err := r.affiniate(ctx, homeID, id)
+		if err != nil {
+			return
+		}
+		out, ok = newAffinalRelationship(homeID, id, r1, r2, u, 1), true
+		return
+	}
+
+	home, _ := r.lookupOne(homeID)
+	person, _ := r.lookupOne(id)
+
+	if m, found := bySpouse[id]; found {
+		spouse, _ := r.lookupOne(m.R1.SourceID)
+		m.Union = entity.NewUnionOf(spouse, home)
+		r2, r1, u := affiniate(ctx, r, homeID, m.MutualRelationship, id)
+		out, ok = newAffinalRelationship(homeID, id, r1, r2, u, m.distance+1), true
+	}
+
+	for _, spouseID := range r.graph.spouseIDs[id] {
+		b, found := blood[spouseID]
+		if !found || slices.Contains(r.parentIDs(homeID), spouseID) {
+			continue
+		}
+		if ok && out.distance <= b.distance+1 {
+			break
+		}
+
+		// The blood relationship is with the home person, but it must be from
+		// the spouse.
+		spouse, _ := r.lookupOne(spouseID)
+		m := b.MutualRelationship
+		m.R1, m.R2 = b.R2, b.R1
+		m.Union = entity.NewUnionOf(spouse, person)
+		r1, r2, u := affiniate(ctx, r, id, m, homeID)
+		out, ok = newAffinalRelationship(homeID, id, r1, r2, u, b.distance+1), true
+		break
+	}
+
+	return
+}
+
+// newAffinalRelationship ranks the relationship from the person at p1ID, r1,
+// and the one from the person at p2ID, r2, through the union u.
+func newAffinalRelationship(p1ID, p2ID string, r1, r2 entity.Relationship, u *entity.Union, distance int) rankedRelationship {
+	r1.SourceID, r1.TargetID = p1ID, p2ID
+	r2.SourceID, r2.TargetID = p2ID, p1ID
+	return rankedRelationship{
+		MutualRelationship: entity.MutualRelationship{Union: u, R1: r1, R2: r2},
+		distance:           distance,
+		affinal:            true,
+	}
+}
@@ -0,0 +1,262 @@
+package srv
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+func (r *relator) RelateEveryWay(ctx context.Context, p1ID, p2ID string) (out []entity.MutualRelationship, err error) {
+	if _, ok := r.lookupOne(p1ID); !ok {
+		err = fmt.Errorf("person with id %v not found", p1ID)
+		return
+	}
+	if _, ok := r.lookupOne(p2ID); !ok {
+		err = fmt.Errorf("person with id %v not found", p2ID)
+		return
+	}
+
+	if p1ID == p2ID {
+		m, rerr := r.Relate(ctx, p1ID, p2ID)
+		if rerr != nil {
+			err = rerr
+			return
+		}
+		out = []entity.MutualRelationship{m}
+		return
+	}
+
+	found, err := r.relateAllByBlood(p1ID, p2ID)
+	if err != nil {
+		return
+	}
+
+	if !r.genetic {
+		affinal, merr := r.relateAllByMarriage(ctx, p1ID, p2ID)
+		if merr != nil {
+			err = merr
+			return
+		}
+		found = append(found, affinal...)
+		found = append(found, r.relateSteps(p1ID, p2ID)...)
+	}
+
+	if len(found) < 1 {
+		err = errUnrelated
+		return
+	}
+
+	// Closer relationships go first. For the same distance, a blood
+	// relationship goes before an affinal one, and a full-blood relationship
+	// goes before a half-blood one.
+	slices.SortStableFunc(found, func(a, b rankedRelationship) int {
+		if c := cmp.Compare(a.distance, b.distance); c != 0 {
+			return c
+		}
+		if a.affinal != b.affinal {
+			if a.affinal {
+				return 1
+			}
+			return -1
+		}
+		if a.half() != b.half() {
+			if a.half() {
+				return 1
+			}
+			return -1
+		}
+		return 0
+	})
+
+	out = make([]entity.MutualRelationship, len(found))
+	for i, rel := range found {
+		out[i] = rel.MutualRelationship
+	}
+	return
+}
+
+// rankedRelationship is a MutualRelationship and its distance, which is the
+// number of parent-child links between the people. Each marriage along the way
+// counts as one more.
+type rankedRelationship struct {
+	entity.MutualRelationship
+	distance int
+	affinal  bool
+}
+
+func (r rankedRelationship) half() bool { return r.R1.Type.IsHalf() }
+
+// relateAllByBlood finds a relationship for each pair of ancestral lines that
+// only meet at a common ancestor. The lines through each member of an
+// ancestral couple are the same relationship, so only one of them is kept.
+func (r *relator) relateAllByBlood(p1ID, p2ID string) (out []rankedRelationship, err error) {
+	return r.relateLinesByBlood(p1ID, r.ancestralLines(p1ID), p2ID, r.ancestralLines(p2ID))
+}
+
+// relateLinesByBlood is like relateAllByBlood, but the ancestralLines of each
+// person are already known.
+func (r *relator) relateLinesByBlood(p1ID string, lines1 map[string][][]string, p2ID string, lines2 map[string][][]string) (out []rankedRelationship, err error) {
+	ancestorIDs := make([]string, 0)
+	for id := range lines1 {
+		if _, ok := lines2[id]; ok {
+			ancestorIDs = append(ancestorIDs, id)
+		}
+	}
+	slices.Sort(ancestorIDs) // ensure deterministic results.
+
+	seen := make(idSet)
+	for _, ancestorID := range ancestorIDs {
+		ancestor, _ := r.lookupOne(ancestorID)
+
+		for _, line1 := range lines1[ancestorID] {
+			for _, line2 := range lines2[ancestorID] {
+				if !meetOnlyAtEnd(line1, line2) {
+					continue
+				}
+				key := strings.Join(line1[:len(line1)-1], ",") + "|" + strings.Join(line2[:len(line2)-1], ",")
+				if seen.has(key) {
+					continue
+				}
+				seen.add(key)
+
+				r1, r2, rerr := makeRelationships(r, line1, line2)
+				if rerr != nil {
+					err = rerr
+					return
+				}
+				r1.SourceID, r1.TargetID = p1ID, p2ID
+				r2.SourceID, r2.TargetID = p2ID, p1ID
+
+				out = append(out, rankedRelationship{
+					MutualRelationship: entity.MutualRelationship{
+						CommonPerson:    ancestor,
+						CommonAncestors: r.ancestralCouple(line1, line2),
+						R1:              r1,
+						R2:              r2,
+					},
+					distance: len(line1) + len(line2) - 2,
+				})
+			}
+		}
+	}
+
+	return
+}
+
+// relateAllByMarriage finds the relationships through a union of either
+// person. Those are the blood relationships of each spouse with the other
+// person. A blood relationship is skipped if the other person descends from
+// the union, because then the spouse is only another parent, or ancestor. It's
+// also skipped if the other person is a child of the spouse, because that's a
+// step relationship.
+func (r *relator) relateAllByMarriage(ctx context.Context, p1ID, p2ID string) (out []rankedRelationship, err error) {
+	if spouses(r, p1ID, p2ID) {
+		r1, r2, u, aerr := r.affiniate(ctx, p1ID, p2ID)
+		if aerr != nil {
+			err = aerr
+			return
+		}
+		r1.SourceID, r1.TargetID = p1ID, p2ID
+		r2.SourceID, r2.TargetID = p2ID, p1ID
+		out = append(out, rankedRelationship{
+			MutualRelationship: entity.MutualRelationship{Union: u, R1: r1, R2: r2},
+			distance:           1,
+			affinal:            true,
+		})
+	}
+
+	for _, pair := range [][2]string{{p1ID, p2ID}, {p2ID, p1ID}} {
+		pID, oID := pair[0], pair[1]
+		for _, spouseID := range r.graph.spouseIDs[pID] {
+			if spouseID == oID || slices.Contains(r.parentIDs(oID), spouseID) || r.descendsFrom(oID, pID, spouseID) {
+				continue
+			}
+
+			blood, rerr := r.relateAllByBlood(spouseID, oID)
+			if rerr != nil {
+				err = rerr
+				return
+			}
+
+			spouse, _ := r.lookupOne(spouseID)
+			p, _ := r.lookupOne(pID)
+			for _, m := range blood {
+				m.Union = entity.NewUnionOf(spouse, p)
+
+				var r1, r2 entity.Relationship
+				var u *entity.Union
+				if pID == p1ID {
+					r2, r1, u = affiniate(ctx, r, pID, m.MutualRelationship, oID)
+				} else {
+					r1, r2, u = affiniate(ctx, r, pID, m.MutualRelationship, oID)
+				}
+				r1.SourceID, r1.TargetID = p1ID, p2ID
+				r2.SourceID, r2.TargetID = p2ID, p1ID
+
+				out = append(out, rankedRelationship{
+					MutualRelationship: entity.MutualRelationship{Union: u, R1: r1, R2: r2},
+					distance:           m.distance + 1,
+					affinal:            true,
+				})
+			}
+		}
+	}
+
+	return
+}
+
+// ancestralLines are all of the lines from the person at id to each of their
+// ancestors, keyed by ancestor ID. The person is also in there, with a line of
+// only themselves.
+func (r *relator) ancestralLines(id string) map[string][][]string {
+	out := make(map[string][][]string)
+
+	var walk func(line []string)
+	walk = func(line []string) {
+		currID := line[len(line)-1]
+		out[currID] = append(out[currID], line)
+		if len(line) > maxGenerationsToRelate {
+			return
+		}
+		for _, parentID := range r.lineParentIDs(currID) {
+			if slices.Contains(line, parentID) {
+				continue // the data has a cycle.
+			}
+			walk(append(slices.Clip(line), parentID))
+		}
+	}
+	walk([]string{id})
+
+	return out
+}
+
+// meetOnlyAtEnd tells if the ancestral lines have no person in common, besides
+// the common ancestor at the end of each line.
+func meetOnlyAtEnd(line1, line2 []string) bool {
+	for _, id := range line1[:len(line1)-1] {
+		if slices.Contains(line2, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// descendsFrom tells if the person at id is a descendant of all of the people
+// at ancestorIDs.
+func (r *relator) descendsFrom(id string, ancestorIDs ...string) bool {
+	remaining := make(idSet, len(ancestorIDs))
+	for _, ancestorID := range ancestorIDs {
+		remaining.add(ancestorID)
+	}
+
+	r.graph.Ancestors(id, maxGenerationsToRelate, func(ancestor *entity.Person, _ int) bool {
+		delete(remaining, ancestor.ID)
+		return len(remaining) > 0
+	})
+
+	return len(remaining) < 1
+}
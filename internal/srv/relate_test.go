@@ -3,12 +3,14 @@ package srv_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/rafaelespinoza/ged/internal/entity"
+	"github.com/rafaelespinoza/ged/internal/entity/date"
 	"github.com/rafaelespinoza/ged/internal/srv"
 )
 
@@ -46,7 +48,7 @@ func buildKennedyFamily(t *testing.T) []*entity.Person {
 		t.Fatal(err)
 	}
 	defer func() { _ = file.Close() }()
-	kennedys, _, err = srv.ParseGedcom(context.Background(), file)
+	kennedys, _, err = srv.ParseGedcom(context.Background(), file, date.ParseOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -162,7 +164,7 @@ func TestRelatorRelate(t *testing.T) {
 					Exp: entity.MutualRelationship{
 						CommonPerson: &entity.Person{ID: jfkMother},
 						R1: entity.Relationship{
-							Description:        "sibling",
+							Description:        "brother",
 							Type:               entity.Sibling,
 							SourceID:           jfk,
 							TargetID:           rfk,
@@ -170,7 +172,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfk}, {ID: jfkMother}},
 						},
 						R2: entity.Relationship{
-							Description:        "sibling",
+							Description:        "brother",
 							Type:               entity.Sibling,
 							SourceID:           rfk,
 							TargetID:           jfk,
@@ -196,7 +198,7 @@ func TestRelatorRelate(t *testing.T) {
 					Exp: entity.MutualRelationship{
 						CommonPerson: &entity.Person{ID: jfkFather},
 						R1: entity.Relationship{
-							Description:        "child",
+							Description:        "son",
 							Type:               entity.Child,
 							SourceID:           jfk,
 							TargetID:           jfkFather,
@@ -204,7 +206,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfk}, {ID: jfkFather}},
 						},
 						R2: entity.Relationship{
-							Description:        "parent",
+							Description:        "father",
 							Type:               entity.Parent,
 							SourceID:           jfkFather,
 							TargetID:           jfk,
@@ -221,7 +223,7 @@ func TestRelatorRelate(t *testing.T) {
 					Exp: entity.MutualRelationship{
 						CommonPerson: &entity.Person{ID: jfkGrandfather},
 						R1: entity.Relationship{
-							Description:        "grand child",
+							Description:        "grandson",
 							Type:               entity.Child,
 							SourceID:           jfk,
 							TargetID:           jfkGrandfather,
@@ -229,7 +231,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfk}, {ID: jfkFather}, {ID: jfkGrandfather}},
 						},
 						R2: entity.Relationship{
-							Description:        "grand parent",
+							Description:        "grandfather",
 							Type:               entity.Parent,
 							SourceID:           jfkGrandfather,
 							TargetID:           jfk,
@@ -255,7 +257,7 @@ func TestRelatorRelate(t *testing.T) {
 					Exp: entity.MutualRelationship{
 						CommonPerson: &entity.Person{ID: jfkFather},
 						R1: entity.Relationship{
-							Description:        "parent",
+							Description:        "father",
 							Type:               entity.Parent,
 							SourceID:           jfkFather,
 							TargetID:           jfk,
@@ -263,7 +265,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfkFather}},
 						},
 						R2: entity.Relationship{
-							Description:        "child",
+							Description:        "son",
 							Type:               entity.Child,
 							SourceID:           jfk,
 							TargetID:           jfkFather,
@@ -280,7 +282,7 @@ func TestRelatorRelate(t *testing.T) {
 					Exp: entity.MutualRelationship{
 						CommonPerson: &entity.Person{ID: jfkGrandfather},
 						R1: entity.Relationship{
-							Description:        "grand parent",
+							Description:        "grandfather",
 							Type:               entity.Parent,
 							SourceID:           jfkGrandfather,
 							TargetID:           jfk,
@@ -288,7 +290,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfkGrandfather}},
 						},
 						R2: entity.Relationship{
-							Description:        "grand child",
+							Description:        "grandson",
 							Type:               entity.Child,
 							SourceID:           jfk,
 							TargetID:           jfkGrandfather,
@@ -314,7 +316,7 @@ func TestRelatorRelate(t *testing.T) {
 					Exp: entity.MutualRelationship{
 						CommonPerson: &entity.Person{ID: jfkMother},
 						R1: entity.Relationship{
-							Description:        "niece/nephew",
+							Description:        "nephew",
 							Type:               entity.NieceNephew,
 							SourceID:           jfkJr,
 							TargetID:           rfk,
@@ -322,7 +324,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfkJr}, {ID: jfk}, {ID: jfkMother}},
 						},
 						R2: entity.Relationship{
-							Description:        "aunt/uncle",
+							Description:        "uncle",
 							Type:               entity.AuntUncle,
 							SourceID:           rfk,
 							TargetID:           jfkJr,
@@ -339,7 +341,7 @@ func TestRelatorRelate(t *testing.T) {
 					Exp: entity.MutualRelationship{
 						CommonPerson: &entity.Person{ID: jfkGrandmother},
 						R1: entity.Relationship{
-							Description:        "grand niece/nephew",
+							Description:        "grandnephew",
 							Type:               entity.NieceNephew,
 							SourceID:           jfkJr,
 							TargetID:           jfkAunt,
@@ -347,7 +349,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfkJr}, {ID: jfk}, {ID: jfkFather}, {ID: jfkGrandmother}},
 						},
 						R2: entity.Relationship{
-							Description:        "great aunt/uncle",
+							Description:        "great aunt",
 							Type:               entity.AuntUncle,
 							SourceID:           jfkAunt,
 							TargetID:           jfkJr,
@@ -373,7 +375,7 @@ func TestRelatorRelate(t *testing.T) {
 					Exp: entity.MutualRelationship{
 						CommonPerson: &entity.Person{ID: jfkMother},
 						R1: entity.Relationship{
-							Description:        "aunt/uncle",
+							Description:        "uncle",
 							Type:               entity.AuntUncle,
 							SourceID:           rfk,
 							TargetID:           jfkJr,
@@ -381,7 +383,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: rfk}, {ID: jfkMother}},
 						},
 						R2: entity.Relationship{
-							Description:        "niece/nephew",
+							Description:        "nephew",
 							Type:               entity.NieceNephew,
 							SourceID:           jfkJr,
 							TargetID:           rfk,
@@ -398,7 +400,7 @@ func TestRelatorRelate(t *testing.T) {
 					Exp: entity.MutualRelationship{
 						CommonPerson: &entity.Person{ID: jfkGrandmother},
 						R1: entity.Relationship{
-							Description:        "great aunt/uncle",
+							Description:        "great aunt",
 							Type:               entity.AuntUncle,
 							SourceID:           jfkAunt,
 							TargetID:           jfkJr,
@@ -406,7 +408,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfkAunt}, {ID: jfkGrandmother}},
 						},
 						R2: entity.Relationship{
-							Description:        "grand niece/nephew",
+							Description:        "grandnephew",
 							Type:               entity.NieceNephew,
 							SourceID:           jfkJr,
 							TargetID:           jfkAunt,
@@ -531,6 +533,148 @@ func TestRelatorRelate(t *testing.T) {
 			}
 		})
 
+		t.Run("half", func(t *testing.T) {
+			// A father has a child with each of 2 mothers.
+			father := &entity.Person{ID: "@I1@"}
+			mother1 := &entity.Person{ID: "@I2@"}
+			mother2 := &entity.Person{ID: "@I3@"}
+			child1 := &entity.Person{ID: "@I4@", Parents: []*entity.Person{father, mother1}}
+			child2 := &entity.Person{ID: "@I5@", Parents: []*entity.Person{father, mother2}}
+			grandchild1 := &entity.Person{ID: "@I6@", Parents: []*entity.Person{child1}}
+			grandchild2 := &entity.Person{ID: "@I7@", Parents: []*entity.Person{child2}}
+			greatGrandchild2 := &entity.Person{ID: "@I8@", Parents: []*entity.Person{grandchild2}}
+			child2Spouse := &entity.Person{ID: "@I9@", Spouses: []*entity.Person{child2}}
+			child2.Spouses = []*entity.Person{child2Spouse}
+			grandchild1Sibling := &entity.Person{ID: "@I10@", Parents: []*entity.Person{child1}}
+			people := []*entity.Person{father, mother1, mother2, child1, child2, grandchild1, grandchild2, greatGrandchild2, child2Spouse, grandchild1Sibling}
+
+			tests := []Testcase{
+				{
+					Name:     entity.HalfSibling.String(),
+					InPeople: people,
+					InP1:     child1.ID,
+					InP2:     child2.ID,
+					Exp: entity.MutualRelationship{
+						CommonPerson: father,
+						R1: entity.Relationship{
+							Description: "half sibling",
+							Type:        entity.HalfSibling,
+							SourceID:    child1.ID,
+							TargetID:    child2.ID,
+							Path:        []entity.Person{*child1, *father},
+						},
+						R2: entity.Relationship{
+							Description: "half sibling",
+							Type:        entity.HalfSibling,
+							SourceID:    child2.ID,
+							TargetID:    child1.ID,
+							Path:        []entity.Person{*child2, *father},
+						},
+					},
+				},
+				{
+					Name:     entity.HalfNieceNephew.String(),
+					InPeople: people,
+					InP1:     grandchild1.ID,
+					InP2:     child2.ID,
+					Exp: entity.MutualRelationship{
+						CommonPerson: father,
+						R1: entity.Relationship{
+							Description:        "half niece/nephew",
+							Type:               entity.HalfNieceNephew,
+							SourceID:           grandchild1.ID,
+							TargetID:           child2.ID,
+							GenerationsRemoved: 1,
+							Path:               []entity.Person{*grandchild1, *child1, *father},
+						},
+						R2: entity.Relationship{
+							Description:        "half aunt/uncle",
+							Type:               entity.HalfAuntUncle,
+							SourceID:           child2.ID,
+							TargetID:           grandchild1.ID,
+							GenerationsRemoved: -1,
+							Path:               []entity.Person{*child2, *father},
+						},
+					},
+				},
+				{
+					Name:     entity.HalfCousin.String(),
+					InPeople: people,
+					InP1:     grandchild1.ID,
+					InP2:     greatGrandchild2.ID,
+					Exp: entity.MutualRelationship{
+						CommonPerson: father,
+						R1: entity.Relationship{
+							Description:        "half 1st cousin 1x removed",
+							Type:               entity.HalfCousin,
+							SourceID:           grandchild1.ID,
+							TargetID:           greatGrandchild2.ID,
+							GenerationsRemoved: -1,
+							Path:               []entity.Person{*grandchild1, *child1, *father},
+						},
+						R2: entity.Relationship{
+							Description:        "half 1st cousin 1x removed",
+							Type:               entity.HalfCousin,
+							SourceID:           greatGrandchild2.ID,
+							TargetID:           grandchild1.ID,
+							GenerationsRemoved: 1,
+							Path:               []entity.Person{*greatGrandchild2, *grandchild2, *child2, *father},
+						},
+					},
+				},
+				{
+					Name:     "only 1 known parent is not half",
+					InPeople: people,
+					InP1:     grandchild1.ID,
+					InP2:     grandchild1Sibling.ID,
+					Exp: entity.MutualRelationship{
+						CommonPerson: child1,
+						R1: entity.Relationship{
+							Description: "sibling",
+							Type:        entity.Sibling,
+							SourceID:    grandchild1.ID,
+							TargetID:    grandchild1Sibling.ID,
+							Path:        []entity.Person{*grandchild1, *child1},
+						},
+						R2: entity.Relationship{
+							Description: "sibling",
+							Type:        entity.Sibling,
+							SourceID:    grandchild1Sibling.ID,
+							TargetID:    grandchild1.ID,
+							Path:        []entity.Person{*grandchild1Sibling, *child1},
+						},
+					},
+				},
+				{
+					Name:     "half sibling in-law",
+					InPeople: people,
+					InP1:     child1.ID,
+					InP2:     child2Spouse.ID,
+					Exp: entity.MutualRelationship{
+						Union: entity.NewUnionOf(child2, child2Spouse),
+						R1: entity.Relationship{
+							Description: "half sibling in-law",
+							Type:        entity.SiblingInLaw,
+							SourceID:    child1.ID,
+							TargetID:    child2Spouse.ID,
+							Path:        []entity.Person{*child1, *father, *child2},
+						},
+						R2: entity.Relationship{
+							Description: "half sibling in-law",
+							Type:        entity.SiblingInLaw,
+							SourceID:    child2Spouse.ID,
+							TargetID:    child1.ID,
+							Path:        []entity.Person{*child2Spouse, *child2},
+						},
+					},
+				},
+			}
+
+			for _, test := range tests {
+				t.Run(test.Name, func(t *testing.T) { runTest(t, test) })
+			}
+		})
+
 		t.Run("affinal", func(t *testing.T) {
 			tests := []Testcase{
 				{
@@ -539,12 +683,9 @@ func TestRelatorRelate(t *testing.T) {
 					InP1:     jfk,
 					InP2:     jfkWife,
 					Exp: entity.MutualRelationship{
-						Union: &entity.Union{
-							Person1: &entity.Person{ID: jfk},
-							Person2: &entity.Person{ID: jfkWife},
-						},
+						Union: entity.NewUnionOf(&entity.Person{ID: jfk}, &entity.Person{ID: jfkWife}),
 						R1: entity.Relationship{
-							Description:        "spouse",
+							Description:        "husband",
 							Type:               entity.Spouse,
 							SourceID:           jfk,
 							TargetID:           jfkWife,
@@ -552,7 +693,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfk}},
 						},
 						R2: entity.Relationship{
-							Description:        "spouse",
+							Description:        "wife",
 							Type:               entity.Spouse,
 							SourceID:           jfkWife,
 							TargetID:           jfk,
@@ -567,12 +708,9 @@ func TestRelatorRelate(t *testing.T) {
 					InP1:     jfkWife,
 					InP2:     rfk,
 					Exp: entity.MutualRelationship{
-						Union: &entity.Union{
-							Person1: &entity.Person{ID: jfk},
-							Person2: &entity.Person{ID: jfkWife},
-						},
+						Union: entity.NewUnionOf(&entity.Person{ID: jfk}, &entity.Person{ID: jfkWife}),
 						R1: entity.Relationship{
-							Description:        "sibling in-law",
+							Description:        "sister in-law",
 							Type:               entity.SiblingInLaw,
 							SourceID:           jfkWife,
 							TargetID:           rfk,
@@ -580,7 +718,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfkWife}, {ID: jfk}},
 						},
 						R2: entity.Relationship{
-							Description:        "sibling in-law",
+							Description:        "brother in-law",
 							Type:               entity.SiblingInLaw,
 							SourceID:           rfk,
 							TargetID:           jfkWife,
@@ -595,12 +733,9 @@ func TestRelatorRelate(t *testing.T) {
 					InP1:     rfk,
 					InP2:     jfkWife,
 					Exp: entity.MutualRelationship{
-						Union: &entity.Union{
-							Person1: &entity.Person{ID: jfk},
-							Person2: &entity.Person{ID: jfkWife},
-						},
+						Union: entity.NewUnionOf(&entity.Person{ID: jfk}, &entity.Person{ID: jfkWife}),
 						R1: entity.Relationship{
-							Description:        "sibling in-law",
+							Description:        "brother in-law",
 							Type:               entity.SiblingInLaw,
 							SourceID:           rfk,
 							TargetID:           jfkWife,
@@ -608,7 +743,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: rfk}, {ID: jfkMother}, {ID: jfk}},
 						},
 						R2: entity.Relationship{
-							Description:        "sibling in-law",
+							Description:        "sister in-law",
 							Type:               entity.SiblingInLaw,
 							SourceID:           jfkWife,
 							TargetID:           rfk,
@@ -623,12 +758,9 @@ func TestRelatorRelate(t *testing.T) {
 					InP1:     jfkWife,
 					InP2:     jfkFather,
 					Exp: entity.MutualRelationship{
-						Union: &entity.Union{
-							Person1: &entity.Person{ID: jfk},
-							Person2: &entity.Person{ID: jfkWife},
-						},
+						Union: entity.NewUnionOf(&entity.Person{ID: jfk}, &entity.Person{ID: jfkWife}),
 						R1: entity.Relationship{
-							Description:        "child in-law",
+							Description:        "daughter in-law",
 							Type:               entity.ChildInLaw,
 							SourceID:           jfkWife,
 							TargetID:           jfkFather,
@@ -636,7 +768,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfkWife}, {ID: jfk}},
 						},
 						R2: entity.Relationship{
-							Description:        "parent in-law",
+							Description:        "father in-law",
 							Type:               entity.ParentInLaw,
 							SourceID:           jfkFather,
 							TargetID:           jfkWife,
@@ -651,12 +783,9 @@ func TestRelatorRelate(t *testing.T) {
 					InP1:     jfkFather,
 					InP2:     jfkWife,
 					Exp: entity.MutualRelationship{
-						Union: &entity.Union{
-							Person1: &entity.Person{ID: jfk},
-							Person2: &entity.Person{ID: jfkWife},
-						},
+						Union: entity.NewUnionOf(&entity.Person{ID: jfk}, &entity.Person{ID: jfkWife}),
 						R1: entity.Relationship{
-							Description:        "parent in-law",
+							Description:        "father in-law",
 							Type:               entity.ParentInLaw,
 							SourceID:           jfkFather,
 							TargetID:           jfkWife,
@@ -664,7 +793,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: jfkFather}, {ID: jfk}},
 						},
 						R2: entity.Relationship{
-							Description:        "child in-law",
+							Description:        "daughter in-law",
 							Type:               entity.ChildInLaw,
 							SourceID:           jfkWife,
 							TargetID:           jfkFather,
@@ -679,12 +808,9 @@ func TestRelatorRelate(t *testing.T) {
 					InP1:     jfk,
 					InP2:     arnold,
 					Exp: entity.MutualRelationship{
-						Union: &entity.Union{
-							Person1: &entity.Person{ID: mariaS},
-							Person2: &entity.Person{ID: arnold},
-						},
+						Union: entity.NewUnionOf(&entity.Person{ID: mariaS}, &entity.Person{ID: arnold}),
 						R1: entity.Relationship{
-							Description:        "aunt/uncle in-law",
+							Description:        "uncle in-law",
 							Type:               entity.AuntUncleInLaw,
 							SourceID:           jfk,
 							TargetID:           arnold,
@@ -692,7 +818,7 @@ func TestRelatorRelate(t *testing.T) {
 							GenerationsRemoved: -1,
 						},
 						R2: entity.Relationship{
-							Description:        "niece/nephew in-law",
+							Description:        "nephew in-law",
 							Type:               entity.NieceNephewInLaw,
 							SourceID:           arnold,
 							TargetID:           jfk,
@@ -707,10 +833,7 @@ func TestRelatorRelate(t *testing.T) {
 					InP1:     arnold,
 					InP2:     jfkJr,
 					Exp: entity.MutualRelationship{
-						Union: &entity.Union{
-							Person1: &entity.Person{ID: mariaS},
-							Person2: &entity.Person{ID: arnold},
-						},
+						Union: entity.NewUnionOf(&entity.Person{ID: mariaS}, &entity.Person{ID: arnold}),
 						R1: entity.Relationship{
 							Description:        "1st cousin in-law",
 							Type:               entity.CousinInLaw,
@@ -735,12 +858,9 @@ func TestRelatorRelate(t *testing.T) {
 					InP1:     arnold,
 					InP2:     jfk,
 					Exp: entity.MutualRelationship{
-						Union: &entity.Union{
-							Person1: &entity.Person{ID: mariaS},
-							Person2: &entity.Person{ID: arnold},
-						},
+						Union: entity.NewUnionOf(&entity.Person{ID: mariaS}, &entity.Person{ID: arnold}),
 						R1: entity.Relationship{
-							Description:        "niece/nephew in-law",
+							Description:        "nephew in-law",
 							Type:               entity.NieceNephewInLaw,
 							SourceID:           arnold,
 							TargetID:           jfk,
@@ -748,7 +868,7 @@ func TestRelatorRelate(t *testing.T) {
 							Path:               []entity.Person{{ID: arnold}, {ID: mariaS}},
 						},
 						R2: entity.Relationship{
-							Description:        "aunt/uncle in-law",
+							Description:        "uncle in-law",
 							Type:               entity.AuntUncleInLaw,
 							SourceID:           jfk,
 							TargetID:           arnold,
@@ -765,6 +885,431 @@ func TestRelatorRelate(t *testing.T) {
 	})
 }
 
+func TestRelatorRelateEveryWay(t *testing.T) {
+	// Siblings from one family marry siblings from another family. Their
+	// children are double first cousins. Then 2 of those cousins marry.
+	grandparents1 := []*entity.Person{{ID: "@I1@"}, {ID: "@I2@"}}
+	grandparents2 := []*entity.Person{{ID: "@I5@"}, {ID: "@I6@"}}
+	parentA := &entity.Person{ID: "@I3@", Parents: grandparents1}
+	parentB := &entity.Person{ID: "@I4@", Parents: grandparents1}
+	parentC := &entity.Person{ID: "@I7@", Parents: grandparents2}
+	parentD := &entity.Person{ID: "@I8@", Parents: grandparents2}
+	cousinX := &entity.Person{ID: "@I9@", Parents: []*entity.Person{parentA, parentC}}
+	cousinY := &entity.Person{ID: "@I10@", Parents: []*entity.Person{parentB, parentD}}
+	cousinZ := &entity.Person{ID: "@I11@", Parents: []*entity.Person{parentB, parentD}}
+	parentA.Spouses, parentC.Spouses = []*entity.Person{parentC}, []*entity.Person{parentA}
+	parentB.Spouses, parentD.Spouses = []*entity.Person{parentD}, []*entity.Person{parentB}
+	cousinX.Spouses, cousinZ.Spouses = []*entity.Person{cousinZ}, []*entity.Person{cousinX}
+
+	people := append(append([]*entity.Person{}, grandparents1...), grandparents2...)
+	people = append(people, parentA, parentB, parentC, parentD, cousinX, cousinY, cousinZ)
+
+	type Expected struct {
+		Description     string
+		CommonAncestors []string
+		Union           bool
+	}
+	tests := []struct {
+		Name       string
+		InP1, InP2 string
+		Exp        []Expected
+	}{
+		{
+			Name: "double first cousins and in-laws",
+			InP1: cousinX.ID, InP2: cousinY.ID,
+			Exp: []Expected{
+				{Description: "sibling in-law", Union: true},
+				{Description: "1st cousin", CommonAncestors: []string{"@I1@", "@I2@"}},
+				{Description: "1st cousin", CommonAncestors: []string{"@I5@", "@I6@"}},
+			},
+		},
+		{
+			Name: "the other parent is not an in-law",
+			InP1: cousinX.ID, InP2: parentA.ID,
+			Exp: []Expected{
+				{Description: "child", CommonAncestors: []string{parentA.ID}},
+				// The parent is also the uncle of the spouse.
+				{Description: "niece/nephew in-law", Union: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual, err := srv.NewRelator(people).RelateEveryWay(context.Background(), test.InP1, test.InP2)
+			if err != nil {
+				t.Fatalf("expected empty error, got %v", err)
+			}
+			if len(actual) != len(test.Exp) {
+				t.Fatalf("wrong number of relationships; got %d, expected %d", len(actual), len(test.Exp))
+			}
+
+			for i, got := range actual {
+				exp := test.Exp[i]
+				errMsgPrefix := fmt.Sprintf("[%d]", i)
+				if got.R1.Description != exp.Description {
+					t.Errorf("%s; wrong Description; got %q, expected %q", errMsgPrefix, got.R1.Description, exp.Description)
+				}
+				if got.R1.SourceID != test.InP1 || got.R1.TargetID != test.InP2 {
+					t.Errorf("%s; wrong IDs; got %q, %q", errMsgPrefix, got.R1.SourceID, got.R1.TargetID)
+				}
+				if (got.Union != nil) != exp.Union {
+					t.Errorf("%s; wrong Union; got %v, expected non-empty? %t", errMsgPrefix, got.Union, exp.Union)
+				}
+				if len(got.CommonAncestors) != len(exp.CommonAncestors) {
+					t.Errorf("%s; wrong number of CommonAncestors; got %d, expected %d", errMsgPrefix, len(got.CommonAncestors), len(exp.CommonAncestors))
+					continue
+				}
+				for j, ancestor := range got.CommonAncestors {
+					testPerson(t, fmt.Sprintf("%s.CommonAncestors[%d]", errMsgPrefix, j), ancestor, &entity.Person{ID: exp.CommonAncestors[j]})
+				}
+			}
+		})
+	}
+}
+
+// buildStepFamily is a blended family, for step relationships.
+func buildStepFamily(t *testing.T) (people []*entity.Person, unions []*entity.Union) {
+	t.Helper()
+
+	// A father has a child with his 1st wife. His 2nd wife has a child from an
+	// earlier union, then a child with him. He divorced another wife before
+	// any of that.
+	father := &entity.Person{ID: "@I1@", Sex: entity.Male}
+	mother := &entity.Person{ID: "@I2@", Sex: entity.Female}
+	child := &entity.Person{ID: "@I3@", Sex: entity.Male, Birthdate: srv.MustParseDate(t, "1955")}
+	wife := &entity.Person{ID: "@I4@", Sex: entity.Female}
+	wifeExHusband := &entity.Person{ID: "@I5@", Sex: entity.Male}
+	wifeChild := &entity.Person{ID: "@I6@", Sex: entity.Female, Birthdate: srv.MustParseDate(t, "1958")}
+	exWife := &entity.Person{ID: "@I7@", Sex: entity.Female}
+	halfSibling := &entity.Person{ID: "@I8@", Birthdate: srv.MustParseDate(t, "1966")}
+	people = []*entity.Person{father, mother, child, wife, wifeExHusband, wifeChild, exWife, halfSibling}
+
+	unions = []*entity.Union{
+		{ID: "@F0@", Partners: []*entity.Partner{{Person: father}, {Person: exWife}}, StartDate: srv.MustParseDate(t, "1940"), EndDate: srv.MustParseDate(t, "1945")},
+		{ID: "@F1@", Partners: []*entity.Partner{{Person: father}, {Person: mother}}, StartDate: srv.MustParseDate(t, "1950"), EndDate: srv.MustParseDate(t, "1960"), Children: []*entity.Person{child}},
+		{ID: "@F2@", Partners: []*entity.Partner{{Person: wifeExHusband}, {Person: wife}}, Children: []*entity.Person{wifeChild}},
+		{ID: "@F3@", Partners: []*entity.Partner{{Person: father}, {Person: wife}}, StartDate: srv.MustParseDate(t, "1965"), Children: []*entity.Person{halfSibling}},
+	}
+	return
+}
+
+func TestRelatorRelateStep(t *testing.T) {
+	people, unions := buildStepFamily(t)
+	father, child, wife, wifeChild, exWife, halfSibling := people[0], people[2], people[3], people[5], people[6], people[7]
+	relator := srv.NewGraph(people, unions).Relator()
+
+	tests := []struct {
+		Name       string
+		InP1, InP2 string
+		Exp        entity.MutualRelationship
+	}{
+		{
+			Name: entity.StepChild.String(),
+			InP1: child.ID, InP2: wife.ID,
+			Exp: entity.MutualRelationship{
+				Union: unions[3],
+				R1: entity.Relationship{
+					Description:        "step-son",
+					Type:               entity.StepChild,
+					SourceID:           child.ID,
+					TargetID:           wife.ID,
+					GenerationsRemoved: 1,
+					Path:               []entity.Person{*child, *father},
+				},
+				R2: entity.Relationship{
+					Description:        "step-mother",
+					Type:               entity.StepParent,
+					SourceID:           wife.ID,
+					TargetID:           child.ID,
+					GenerationsRemoved: -1,
+					Path:               []entity.Person{*wife},
+				},
+			},
+		},
+		{
+			Name: entity.StepParent.String(),
+			InP1: wife.ID, InP2: child.ID,
+			Exp: entity.MutualRelationship{
+				Union: unions[3],
+				R1: entity.Relationship{
+					Description:        "step-mother",
+					Type:               entity.StepParent,
+					SourceID:           wife.ID,
+					TargetID:           child.ID,
+					GenerationsRemoved: -1,
+					Path:               []entity.Person{*wife},
+				},
+				R2: entity.Relationship{
+					Description:        "step-son",
+					Type:               entity.StepChild,
+					SourceID:           child.ID,
+					TargetID:           wife.ID,
+					GenerationsRemoved: 1,
+					Path:               []entity.Person{*child, *father},
+				},
+			},
+		},
+		{
+			Name: entity.StepSibling.String(),
+			InP1: child.ID, InP2: wifeChild.ID,
+			Exp: entity.MutualRelationship{
+				Union: unions[3],
+				R1: entity.Relationship{
+					Description: "step-brother",
+					Type:        entity.StepSibling,
+					SourceID:    child.ID,
+					TargetID:    wifeChild.ID,
+					Path:        []entity.Person{*child, *father},
+				},
+				R2: entity.Relationship{
+					Description: "step-sister",
+					Type:        entity.StepSibling,
+					SourceID:    wifeChild.ID,
+					TargetID:    child.ID,
+					Path:        []entity.Person{*wifeChild, *wife},
+				},
+			},
+		},
+		{
+			Name: "half sibling is not a step-sibling",
+			InP1: child.ID, InP2: halfSibling.ID,
+			Exp: entity.MutualRelationship{
+				CommonPerson: father,
+				R1: entity.Relationship{
+					Description: "half brother",
+					Type:        entity.HalfSibling,
+					SourceID:    child.ID,
+					TargetID:    halfSibling.ID,
+					Path:        []entity.Person{*child, *father},
+				},
+				R2: entity.Relationship{
+					Description: "half sibling",
+					Type:        entity.HalfSibling,
+					SourceID:    halfSibling.ID,
+					TargetID:    child.ID,
+					Path:        []entity.Person{*halfSibling, *father},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual, err := relator.Relate(context.Background(), test.InP1, test.InP2)
+			if err != nil {
+				t.Fatalf("expected empty error, got %v", err)
+			}
+
+			testPerson(t, ".CommonPerson", actual.CommonPerson, test.Exp.CommonPerson)
+			testUnion(t, ".Union", actual.Union, test.Exp.Union)
+			testRelationship(t, ".R1", actual.R1, test.Exp.R1)
+			testRelationship(t, ".R2", actual.R2, test.Exp.R2)
+		})
+	}
+
+	t.Run("union ended before birth", func(t *testing.T) {
+		_, err := relator.Relate(context.Background(), child.ID, exWife.ID)
+		if err == nil {
+			t.Fatal("expected non-empty error")
+		}
+		if !strings.Contains(err.Error(), "unrelated") {
+			t.Errorf("expected error message (%q) to contain %q", err.Error(), "unrelated")
+		}
+	})
+}
+
+func TestRelatorRelateAll(t *testing.T) {
+	people := buildKennedyFamily(t)
+	relator := srv.NewRelator(people)
+	ctx := context.Background()
+
+	actual := testRelateAllLikeRelate(t, relator, people, jfk)
+
+	// Blood relatives are first, closest first.
+	if len(actual) < 1 || actual[0].Union != nil || len(actual[0].R1.Path)+len(actual[0].R2.Path) != 3 {
+		t.Errorf("expected the first result to be a parent or child")
+	}
+
+	t.Run("step relatives", func(t *testing.T) {
+		people, unions := buildStepFamily(t)
+		relator := srv.NewGraph(people, unions).Relator()
+		for _, home := range people {
+			t.Run(home.ID, func(t *testing.T) {
+				testRelateAllLikeRelate(t, relator, people, home.ID)
+			})
+		}
+
+		// The child (@I3@) has a step-mother (@I4@) and a step-sister (@I6@).
+		actual := testRelateAllLikeRelate(t, relator, people, "@I3@")
+		exp := map[string]string{"@I4@": "step-mother", "@I6@": "step-sister"}
+		for _, got := range actual {
+			if desc, ok := exp[got.R2.SourceID]; ok && got.R2.Description != desc {
+				t.Errorf("%s; wrong description; got %q, expected %q", got.R2.SourceID, got.R2.Description, desc)
+			}
+			delete(exp, got.R2.SourceID)
+		}
+		for id := range exp {
+			t.Errorf("%s; expected a result", id)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := relator.RelateAll(ctx, "@I999@"); err == nil {
+			t.Error("expected non-empty error")
+		}
+	})
+}
+
+// testRelateAllLikeRelate checks that each result of RelateAll, from the
+// person at homeID, is the same as relating the people one at a time.
+func testRelateAllLikeRelate(t *testing.T, relator srv.Relator, people []*entity.Person, homeID string) []entity.MutualRelationship {
+	t.Helper()
+	ctx := context.Background()
+
+	actual, err := relator.RelateAll(ctx, homeID)
+	if err != nil {
+		t.Fatalf("expected empty error, got %v", err)
+	}
+
+	actualByID := make(map[string]entity.MutualRelationship, len(actual))
+	for _, got := range actual {
+		if got.R1.SourceID != homeID {
+			t.Errorf("wrong R1.SourceID; got %q, expected %q", got.R1.SourceID, homeID)
+		}
+		if _, ok := actualByID[got.R1.TargetID]; ok {
+			t.Errorf("duplicate result for %q", got.R1.TargetID)
+		}
+		actualByID[got.R1.TargetID] = got
+	}
+	for _, person := range people {
+		if person.ID == homeID {
+			continue
+		}
+		exp, err := relator.Relate(ctx, homeID, person.ID)
+		got, ok := actualByID[person.ID]
+		if err != nil {
+			if ok {
+				t.Errorf("%s; expected no result, got %q", person.ID, got.R2.Description)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("%s; expected a result, %q", person.ID, exp.R2.Description)
+			continue
+		}
+		if got.R1.Description != exp.R1.Description || got.R2.Description != exp.R2.Description {
+			t.Errorf("%s; wrong descriptions; got %q, %q, expected %q, %q", person.ID, got.R1.Description, got.R2.Description, exp.R1.Description, exp.R2.Description)
+		}
+		if got.R2.GenerationsRemoved != exp.R2.GenerationsRemoved {
+			t.Errorf("%s; wrong GenerationsRemoved; got %d, expected %d", person.ID, got.R2.GenerationsRemoved, exp.R2.GenerationsRemoved)
+		}
+	}
+
+	return actual
+}
+
+func TestRelatorRelatePedigree(t *testing.T) {
+	// A couple has a birth child, an adopted child and a foster child. The
+	// adopted child has a child by birth.
+	father := &entity.Person{ID: "@I1@", Sex: entity.Male}
+	mother := &entity.Person{ID: "@I2@", Sex: entity.Female}
+	parents := []*entity.Person{father, mother}
+	birthChild := &entity.Person{ID: "@I3@", Parents: parents}
+	adoptedChild := &entity.Person{
+		ID:              "@I4@",
+		Sex:             entity.Female,
+		Parents:         parents,
+		ParentPedigrees: map[string]entity.Pedigree{father.ID: entity.Adopted, mother.ID: entity.Adopted},
+	}
+	fosterChild := &entity.Person{
+		ID:              "@I5@",
+		Parents:         parents,
+		ParentPedigrees: map[string]entity.Pedigree{father.ID: entity.Foster, mother.ID: entity.Foster},
+	}
+	grandchild := &entity.Person{ID: "@I6@", Parents: []*entity.Person{adoptedChild}}
+	father.Spouses, mother.Spouses = []*entity.Person{mother}, []*entity.Person{father}
+	people := []*entity.Person{father, mother, birthChild, adoptedChild, fosterChild, grandchild}
+	graph := srv.NewGraph(people, nil)
+
+	tests := []struct {
+		Name         string
+		InP1, InP2   string
+		Exp1, Exp2   string
+		ExpPedigree  entity.Pedigree
+		ExpUnrelated bool
+	}{
+		{
+			Name: "adopted child",
+			InP1: adoptedChild.ID, InP2: father.ID,
+			Exp1: "adopted daughter", Exp2: "adoptive father",
+			ExpPedigree: entity.Adopted,
+		},
+		{
+			Name: "adoptive sibling",
+			InP1: birthChild.ID, InP2: adoptedChild.ID,
+			Exp1: "adoptive sibling", Exp2: "adoptive sister",
+			ExpPedigree: entity.Adopted,
+		},
+		{
+			Name: "foster outranks adopted",
+			InP1: fosterChild.ID, InP2: adoptedChild.ID,
+			Exp1: "foster sibling", Exp2: "foster sister",
+			ExpPedigree: entity.Foster,
+		},
+		{
+			Name: "through an adopted parent",
+			InP1: grandchild.ID, InP2: birthChild.ID,
+			Exp1: "adoptive niece/nephew", Exp2: "adoptive aunt/uncle",
+			ExpPedigree: entity.Adopted,
+		},
+		{
+			Name: "birth",
+			InP1: birthChild.ID, InP2: mother.ID,
+			Exp1: "child", Exp2: "mother",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			actual, err := graph.Relator().Relate(context.Background(), test.InP1, test.InP2)
+			if err != nil {
+				t.Fatalf("expected empty error, got %v", err)
+			}
+			if actual.R1.Description != test.Exp1 {
+				t.Errorf("wrong R1.Description; got %q, expected %q", actual.R1.Description, test.Exp1)
+			}
+			if actual.R2.Description != test.Exp2 {
+				t.Errorf("wrong R2.Description; got %q, expected %q", actual.R2.Description, test.Exp2)
+			}
+			if actual.R1.Pedigree != test.ExpPedigree || actual.R2.Pedigree != test.ExpPedigree {
+				t.Errorf("wrong Pedigree; got %q, %q, expected %q", actual.R1.Pedigree, actual.R2.Pedigree, test.ExpPedigree)
+			}
+		})
+	}
+
+	t.Run("genetic", func(t *testing.T) {
+		relator := graph.GeneticRelator()
+
+		actual, err := relator.Relate(context.Background(), birthChild.ID, mother.ID)
+		if err != nil {
+			t.Fatalf("expected empty error, got %v", err)
+		}
+		if actual.R1.Description != "child" {
+			t.Errorf("wrong Description; got %q, expected %q", actual.R1.Description, "child")
+		}
+
+		for _, pair := range [][2]string{{birthChild.ID, adoptedChild.ID}, {grandchild.ID, father.ID}, {father.ID, mother.ID}} {
+			if _, err = relator.Relate(context.Background(), pair[0], pair[1]); err == nil {
+				t.Errorf("expected non-empty error for %q, %q", pair[0], pair[1])
+			}
+			if _, err = relator.RelateEveryWay(context.Background(), pair[0], pair[1]); err == nil {
+				t.Errorf("expected non-empty error from RelateEveryWay for %q, %q", pair[0], pair[1])
+			}
+		}
+	})
+}
+
 func testPerson(t *testing.T, errMsgPrefix string, actual, expected *entity.Person) {
 	t.Helper()
 
@@ -790,8 +1335,17 @@ func testUnion(t *testing.T, errMsgPrefix string, actual, expected *entity.Union
 	} else if actual == nil && expected != nil {
 		t.Errorf("%s; expected union to be non-nil", errMsgPrefix)
 	} else if actual != nil && expected != nil {
-		testPerson(t, errMsgPrefix+".Person1", actual.Person1, expected.Person1)
-		testPerson(t, errMsgPrefix+".Person2", actual.Person2, expected.Person2)
+		if len(actual.Partners) != len(expected.Partners) {
+			t.Errorf("%s; wrong number of Partners; got %d, expected %d", errMsgPrefix, len(actual.Partners), len(expected.Partners))
+			return
+		}
+		for i, partner := range actual.Partners {
+			errMsgPrefix := fmt.Sprintf("%s.Partners[%d]", errMsgPrefix, i)
+			testPerson(t, errMsgPrefix, partner.Person, expected.Partners[i].Person)
+			if partner.Role != expected.Partners[i].Role {
+				t.Errorf("%s; wrong Role; got %q, expected %q", errMsgPrefix, partner.Role, expected.Partners[i].Role)
+			}
+		}
 	}
 }
 
@@ -2,6 +2,7 @@ package srv
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -60,42 +61,183 @@ func makeRelationships(r *relator, line1, line2 []string) (out1, out2 entity.Rel
 		dist2 *= -1
 	}
 
-	desc, _, err := describeRelationship(out1.Type, out1.GenerationsRemoved, dist1)
+	if r.halfBlood(line1, line2) {
+		out1.Type, out2.Type = out1.Type.Half(), out2.Type.Half()
+	}
+
+	desc, _, err := describeRelationship(out1.Type, r.sexOf(line1[0]), out1.GenerationsRemoved, dist1)
 	if err != nil {
 		err = fmt.Errorf("lineage 1: %w", err)
 		return
 	}
 	out1.Description = desc
 
-	desc, _, err = describeRelationship(out2.Type, out2.GenerationsRemoved, dist2)
+	desc, _, err = describeRelationship(out2.Type, r.sexOf(line2[0]), out2.GenerationsRemoved, dist2)
 	if err != nil {
 		err = fmt.Errorf("lineage 2: %w", err)
 		return
 	}
 	out2.Description = desc
 
+	out1.Pedigree = r.linePedigree(line1, line2)
+	out2.Pedigree = out1.Pedigree
+	out1.Description = qualifyDescription(out1.Description, out1.Type, out1.Pedigree)
+	out2.Description = qualifyDescription(out2.Description, out2.Type, out2.Pedigree)
+
 	return
 }
 
+// pedigreeRanks orders the pedigrees of the links along a relationship. The
+// highest one describes the whole relationship.
+var pedigreeRanks = map[entity.Pedigree]int{
+	entity.Sealing:       1,
+	entity.OtherPedigree: 1,
+	entity.Adopted:       2,
+	entity.Foster:        3,
+}
+
+// linePedigree is the highest ranked pedigree of each parent and child along
+// the ancestral lines. It's empty if they are all related by birth.
+func (r *relator) linePedigree(lines ...[]string) (out entity.Pedigree) {
+	for _, line := range lines {
+		for i := 0; i+1 < len(line); i++ {
+			pedigree := r.graph.Pedigree(line[i], line[i+1])
+			if pedigreeRanks[pedigree] > pedigreeRanks[out] {
+				out = pedigree
+			}
+		}
+	}
+	return
+}
+
+// qualifyDescription marks a description of a relationship through an adoption
+// or a foster family, like "adopted son", "adoptive father" or "foster sibling".
+// Any other pedigree leaves the description as is.
+func qualifyDescription(desc string, t entity.RelationshipType, p entity.Pedigree) string {
+	if desc == "" {
+		return desc
+	}
+
+	switch p {
+	case entity.Adopted:
+		if t.Whole() == entity.Child {
+			return "adopted " + desc
+		}
+		return "adoptive " + desc
+	case entity.Foster:
+		return "foster " + desc
+	default:
+		return desc
+	}
+}
+
 func buildCommonAncestors(r *relator, path []string) []entity.Person {
 	out := make([]entity.Person, len(path))
 
 	for i, personID := range path {
 		person, _ := r.lookupOne(personID)
 		out[i] = entity.Person{
-			ID:        person.ID,
-			Name:      person.Name,
-			Birthdate: person.Birthdate,
-			Deathdate: person.Deathdate,
+			ID:           person.ID,
+			Name:         person.Name,
+			Birthdate:    person.Birthdate,
+			Deathdate:    person.Deathdate,
+			LivingStatus: person.LivingStatus,
+		}
+	}
+
+	return out
+}
+
+// halfBlood tells if the ancestral lines only meet at one member of the
+// ancestral couple. The people on each line just below the common ancestor are
+// full siblings if they have another parent in common, or if they are children
+// of the same union. If neither of them has any other known parent, then they
+// are assumed to be full siblings.
+func (r *relator) halfBlood(line1, line2 []string) bool {
+	if len(line1) < 2 || len(line2) < 2 {
+		return false // direct line
+	}
+
+	ancestorID := line1[len(line1)-1]
+	id1, id2 := line1[len(line1)-2], line2[len(line2)-2]
+	if id1 == id2 {
+		return false
+	}
+
+	parentIDs1, parentIDs2 := r.parentIDs(id1), r.parentIDs(id2)
+	for _, parentID := range parentIDs1 {
+		if parentID != ancestorID && slices.Contains(parentIDs2, parentID) {
+			return false
 		}
 	}
 
+	unionIDs1, unionIDs2 := r.graph.unionIDsAsChild[id1], r.graph.unionIDsAsChild[id2]
+	for _, unionID := range unionIDs1 {
+		if slices.Contains(unionIDs2, unionID) {
+			return false
+		}
+	}
+	if len(unionIDs1) > 0 && len(unionIDs2) > 0 {
+		return true
+	}
+
+	isOther := func(id string) bool { return id != ancestorID }
+	return slices.ContainsFunc(parentIDs1, isOther) || slices.ContainsFunc(parentIDs2, isOther)
+}
+
+// ancestralCouple is the common ancestor at the end of the lines, and their
+// partner if the partner is also a parent of the people just below them on
+// each line.
+func (r *relator) ancestralCouple(line1, line2 []string) (out []*entity.Person) {
+	ancestorID := line1[len(line1)-1]
+	if ancestor, ok := r.lookupOne(ancestorID); ok {
+		out = append(out, ancestor)
+	}
+	if len(line1) < 2 || len(line2) < 2 {
+		return // direct line
+	}
+
+	id1, id2 := line1[len(line1)-2], line2[len(line2)-2]
+	for _, parentID := range r.parentIDs(id1) {
+		if parentID == ancestorID || !slices.Contains(r.parentIDs(id2), parentID) {
+			continue
+		}
+		if parent, ok := r.lookupOne(parentID); ok {
+			out = append(out, parent)
+		}
+	}
+	return
+}
+
+func pathIDs(path []entity.Person) []string {
+	out := make([]string, len(path))
+	for i, person := range path {
+		out[i] = person.ID
+	}
 	return out
 }
 
-func describeRelationship(t entity.RelationshipType, generationsRemoved int, generationsSinceCommonAncestor int) (out string, related bool, err error) {
+// describeRelationship names the relationship for a person of the given sex,
+// like "great grandmother". If the sex is unknown, then the name is neutral,
+// like "great grand parent".
+func describeRelationship(t entity.RelationshipType, sex entity.Sex, generationsRemoved int, generationsSinceCommonAncestor int) (out string, related bool, err error) {
+	if t.IsHalf() {
+		out, related, err = describeRelationship(t.Whole(), sex, generationsRemoved, generationsSinceCommonAncestor)
+		if related {
+			out = "half " + out
+		}
+		return
+	}
+
 	related = true
 
+	// A gendered name is joined to the "grand" part, like "grandson". A
+	// neutral one is not, like "grand child".
+	name, grand := t.Term(sex), "grand "
+	if name != t.String() {
+		grand = "grand"
+	}
+
 	switch t {
 	case entity.Child, entity.NieceNephew:
 		if generationsRemoved <= 0 {
@@ -103,26 +245,26 @@ func describeRelationship(t entity.RelationshipType, generationsRemoved int, gen
 		} else {
 			switch generationsRemoved {
 			case 1:
-				out = t.String()
+				out = name
 			case 2:
-				out = "grand " + t.String()
+				out = grand + name
 			default:
-				out = strings.Repeat("great ", generationsRemoved-2) + "grand " + t.String()
+				out = strings.Repeat("great ", generationsRemoved-2) + grand + name
 			}
 		}
-	case entity.Self, entity.Sibling:
-		out = t.String()
+	case entity.Self, entity.Sibling, entity.StepParent, entity.StepChild, entity.StepSibling:
+		out = name
 	case entity.Parent:
 		if generationsRemoved >= 0 {
 			err = fmt.Errorf("generations removed (%d) must be < 0 for parent", generationsRemoved)
 		} else {
 			switch generationsRemoved {
 			case -1:
-				out = t.String()
+				out = name
 			case -2:
-				out = "grand " + t.String()
+				out = grand + name
 			default:
-				out = strings.Repeat("great ", -generationsRemoved-2) + "grand " + t.String()
+				out = strings.Repeat("great ", -generationsRemoved-2) + grand + name
 			}
 		}
 	case entity.AuntUncle:
@@ -131,11 +273,11 @@ func describeRelationship(t entity.RelationshipType, generationsRemoved int, gen
 		} else {
 			switch generationsRemoved {
 			case -1:
-				out = t.String()
+				out = name
 			case -2:
-				out = "great " + t.String()
+				out = "great " + name
 			default:
-				out = strings.Repeat("great ", -generationsRemoved-2) + "grand " + t.String()
+				out = strings.Repeat("great ", -generationsRemoved-2) + grand + name
 			}
 		}
 	case entity.Cousin:
@@ -151,7 +293,7 @@ func describeRelationship(t entity.RelationshipType, generationsRemoved int, gen
 			n := -generationsSinceCommonAncestor - 1
 
 			if generationsRemoved == 0 {
-				out = makeOrdinalSuffix(n) + " " + t.String()
+				out = makeOrdinalSuffix(n) + " " + name
 			} else if generationsRemoved >= -20 && generationsRemoved <= 20 {
 				if generationsRemoved < 0 {
 					generationsRemoved *= -1
@@ -160,9 +302,9 @@ func describeRelationship(t entity.RelationshipType, generationsRemoved int, gen
 				}
 
 				ordSuff := makeOrdinalSuffix(n)
-				out = ordSuff + " " + t.String() + " " + strconv.Itoa(generationsRemoved) + "x removed"
+				out = ordSuff + " " + name + " " + strconv.Itoa(generationsRemoved) + "x removed"
 			} else {
-				out = "distant " + t.String()
+				out = "distant " + name
 			}
 		}
 	default:
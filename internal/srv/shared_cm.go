@@ -0,0 +1,140 @@
+package srv
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+// SharedCM is how much autosomal DNA two people are expected to share, in
+// centimorgans (cM), for a relationship. Low and High are the range that most
+// pairs of people fall within.
+type SharedCM struct {
+	// Relationship is a neutral name of the relationship, like "1st cousin 1x
+	// removed".
+	Relationship string
+	Low, High    float64
+	Average      float64
+}
+
+// Contains tells if cm is within the range.
+func (s SharedCM) Contains(cm float64) bool { return cm >= s.Low && cm <= s.High }
+
+// sharedCMKey identifies a blood relationship by the number of generations
+// from each person to the common ancestor, fewest first.
+type sharedCMKey struct {
+	near, far int
+	half      bool
+}
+
+// sharedCMTable are the ranges from the Shared cM Project, version 4 (March
+// 2020) by Blaine Bettinger, where the range is the 99th percentile of the
+// submissions. Relationships that are not in here have too few submissions.
+var sharedCMTable = map[sharedCMKey]SharedCM{
+	{0, 1, false}: {Relationship: "parent/child", Low: 2376, Average: 3485, High: 3720},
+	{0, 2, false}: {Relationship: "grand parent/grand child", Low: 984, Average: 1754, High: 2462},
+	{0, 3, false}: {Relationship: "great grand parent/great grand child", Low: 485, Average: 887, High: 1486},
+	{1, 1, false}: {Relationship: "sibling", Low: 1613, Average: 2613, High: 3488},
+	{1, 1, true}:  {Relationship: "half sibling", Low: 1160, Average: 1759, High: 2436},
+	{1, 2, false}: {Relationship: "aunt/uncle/niece/nephew", Low: 1201, Average: 1741, High: 2282},
+	{1, 2, true}:  {Relationship: "half aunt/uncle/niece/nephew", Low: 492, Average: 871, High: 1315},
+	{1, 3, false}: {Relationship: "great aunt/uncle/grand niece/nephew", Low: 330, Average: 850, High: 1467},
+	{1, 3, true}:  {Relationship: "half great aunt/uncle/grand niece/nephew", Low: 125, Average: 431, High: 765},
+	{2, 2, false}: {Relationship: "1st cousin", Low: 396, Average: 866, High: 1397},
+	{2, 2, true}:  {Relationship: "half 1st cousin", Low: 156, Average: 449, High: 979},
+	{2, 3, false}: {Relationship: "1st cousin 1x removed", Low: 102, Average: 433, High: 980},
+	{2, 3, true}:  {Relationship: "half 1st cousin 1x removed", Low: 57, Average: 224, High: 530},
+	{2, 4, false}: {Relationship: "1st cousin 2x removed", Low: 33, Average: 221, High: 471},
+	{2, 4, true}:  {Relationship: "half 1st cousin 2x removed", Low: 14, Average: 125, High: 315},
+	{2, 5, false}: {Relationship: "1st cousin 3x removed", Low: 0, Average: 117, High: 282},
+	{3, 3, false}: {Relationship: "2nd cousin", Low: 41, Average: 229, High: 592},
+	{3, 3, true}:  {Relationship: "half 2nd cousin", Low: 10, Average: 120, High: 325},
+	{3, 4, false}: {Relationship: "2nd cousin 1x removed", Low: 0, Average: 122, High: 353},
+	{3, 4, true}:  {Relationship: "half 2nd cousin 1x removed", Low: 0, Average: 70, High: 231},
+	{3, 5, false}: {Relationship: "2nd cousin 2x removed", Low: 0, Average: 71, High: 261},
+	{4, 4, false}: {Relationship: "3rd cousin", Low: 0, Average: 73, High: 234},
+	{4, 5, false}: {Relationship: "3rd cousin 1x removed", Low: 0, Average: 48, High: 192},
+	{4, 6, false}: {Relationship: "3rd cousin 2x removed", Low: 0, Average: 35, High: 165},
+	{5, 5, false}: {Relationship: "4th cousin", Low: 0, Average: 35, High: 139},
+	{5, 6, false}: {Relationship: "4th cousin 1x removed", Low: 0, Average: 28, High: 126},
+	{6, 6, false}: {Relationship: "5th cousin", Low: 0, Average: 25, High: 117},
+}
+
+// SharedCMTable is the reference table of expected shared DNA, closest
+// relationships first.
+func SharedCMTable() []SharedCM {
+	out := make([]SharedCM, 0, len(sharedCMTable))
+	for _, val := range sharedCMTable {
+		out = append(out, val)
+	}
+	slices.SortFunc(out, func(a, b SharedCM) int {
+		if c := cmp.Compare(b.Average, a.Average); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Relationship, b.Relationship)
+	})
+	return out
+}
+
+// ExpectedSharedCM looks up the expected shared DNA for a blood relationship.
+// It's not ok if the relationship is not by blood, or if it's not in the
+// reference table.
+func ExpectedSharedCM(m entity.MutualRelationship) (out SharedCM, ok bool) {
+	if m.CommonPerson == nil || m.Union != nil || len(m.R1.Path) < 1 || len(m.R2.Path) < 1 {
+		return
+	}
+	if m.R1.Type == entity.Self || m.R1.Pedigree != "" {
+		return
+	}
+
+	near, far := len(m.R1.Path)-1, len(m.R2.Path)-1
+	if near > far {
+		near, far = far, near
+	}
+	out, ok = sharedCMTable[sharedCMKey{near: near, far: far, half: m.R1.Type.IsHalf()}]
+	return
+}
+
+// SharedCMMatch is a person whose genetic relationship with someone else is
+// compatible with an amount of shared DNA.
+type SharedCMMatch struct {
+	Person       *entity.Person
+	Relationship entity.MutualRelationship
+	Expected     SharedCM
+}
+
+// MatchSharedCM finds the people whose closest genetic relationship with the
+// person at id is expected to share cm centimorgans of DNA with them. The
+// closest matches, by the average of the expected range, are first.
+func (g *Graph) MatchSharedCM(ctx context.Context, id string, cm float64) (out []SharedCMMatch, err error) {
+	if _, ok := g.Person(id); !ok {
+		err = fmt.Errorf("person with id %v not found", id)
+		return
+	}
+	if cm < 0 {
+		err = fmt.Errorf("shared cM (%v) must be >= 0", cm)
+		return
+	}
+
+	relationships, err := g.GeneticRelator().RelateAll(ctx, id)
+	if err != nil {
+		return
+	}
+
+	for _, m := range relationships {
+		expected, ok := ExpectedSharedCM(m)
+		if !ok || !expected.Contains(cm) {
+			continue
+		}
+		person, _ := g.Person(m.R1.TargetID)
+		out = append(out, SharedCMMatch{Person: person, Relationship: m, Expected: expected})
+	}
+
+	slices.SortStableFunc(out, func(a, b SharedCMMatch) int {
+		return cmp.Compare(b.Expected.Average, a.Expected.Average)
+	})
+	return
+}
@@ -0,0 +1,99 @@
+package srv
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+func TestSharedCM(t *testing.T) {
+	// The grandparents @I1@ and @I2@ have the children @I3@ and @I4@. Each of
+	// those has a child, @I5@ and @I6@, who are 1st cousins. The grandfather
+	// @I1@ also has a child @I7@ with @I8@. The person @I9@ is adopted by @I3@.
+	grandpa := &entity.Person{ID: "@I1@"}
+	grandma := &entity.Person{ID: "@I2@"}
+	parentA := &entity.Person{ID: "@I3@", Parents: []*entity.Person{grandpa, grandma}}
+	parentB := &entity.Person{ID: "@I4@", Parents: []*entity.Person{grandpa, grandma}}
+	cousinX := &entity.Person{ID: "@I5@", Parents: []*entity.Person{parentA}}
+	cousinY := &entity.Person{ID: "@I6@", Parents: []*entity.Person{parentB}}
+	halfSibling := &entity.Person{ID: "@I7@", Parents: []*entity.Person{grandpa, {ID: "@I8@"}}}
+	adopted := &entity.Person{
+		ID:              "@I9@",
+		Parents:         []*entity.Person{parentA},
+		ParentPedigrees: map[string]entity.Pedigree{parentA.ID: entity.Adopted},
+	}
+	people := []*entity.Person{grandpa, grandma, parentA, parentB, cousinX, cousinY, halfSibling, {ID: "@I8@"}, adopted}
+	graph := NewGraph(people, nil)
+
+	t.Run("ExpectedSharedCM", func(t *testing.T) {
+		tests := []struct {
+			Name       string
+			InP1, InP2 string
+			ExpOK      bool
+			Exp        string
+		}{
+			{Name: "parent", InP1: parentA.ID, InP2: grandpa.ID, ExpOK: true, Exp: "parent/child"},
+			{Name: "child", InP1: grandpa.ID, InP2: parentA.ID, ExpOK: true, Exp: "parent/child"},
+			{Name: "sibling", InP1: parentA.ID, InP2: parentB.ID, ExpOK: true, Exp: "sibling"},
+			{Name: "half sibling", InP1: parentA.ID, InP2: halfSibling.ID, ExpOK: true, Exp: "half sibling"},
+			{Name: "niece/nephew", InP1: cousinX.ID, InP2: parentB.ID, ExpOK: true, Exp: "aunt/uncle/niece/nephew"},
+			{Name: "1st cousin", InP1: cousinX.ID, InP2: cousinY.ID, ExpOK: true, Exp: "1st cousin"},
+			{Name: "adopted", InP1: adopted.ID, InP2: parentA.ID},
+			{Name: "self", InP1: parentA.ID, InP2: parentA.ID},
+		}
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				m, err := graph.Relator().Relate(context.Background(), test.InP1, test.InP2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, ok := ExpectedSharedCM(m)
+				if ok != test.ExpOK {
+					t.Fatalf("wrong ok; got %t, expected %t", ok, test.ExpOK)
+				}
+				if got.Relationship != test.Exp {
+					t.Errorf("wrong Relationship; got %q, expected %q", got.Relationship, test.Exp)
+				}
+			})
+		}
+	})
+
+	t.Run("MatchSharedCM", func(t *testing.T) {
+		got, err := graph.MatchSharedCM(context.Background(), cousinX.ID, 1500)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotIDs := make([]string, len(got))
+		for i, match := range got {
+			gotIDs[i] = match.Person.ID
+		}
+		// A parent is outside of the range, and the adopted sibling is not a
+		// genetic relative.
+		expIDs := []string{grandpa.ID, grandma.ID, parentB.ID}
+		if !slices.Equal(gotIDs, expIDs) {
+			t.Errorf("wrong IDs; got %q, expected %q", gotIDs, expIDs)
+		}
+
+		if _, err = graph.MatchSharedCM(context.Background(), "@I999@", 1500); err == nil {
+			t.Error("expected non-empty error for an unknown person")
+		}
+	})
+
+	t.Run("SharedCMTable", func(t *testing.T) {
+		got := SharedCMTable()
+		if len(got) != len(sharedCMTable) {
+			t.Fatalf("wrong length; got %d, expected %d", len(got), len(sharedCMTable))
+		}
+		if got[0].Relationship != "parent/child" {
+			t.Errorf("wrong first item; got %q, expected %q", got[0].Relationship, "parent/child")
+		}
+		for _, row := range got {
+			if row.Low > row.Average || row.Average > row.High {
+				t.Errorf("%q; expected Low <= Average <= High, got %v, %v, %v", row.Relationship, row.Low, row.Average, row.High)
+			}
+		}
+	})
+}
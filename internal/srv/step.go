@@ -0,0 +1,157 @@
+package srv
+
+import (
+	"slices"
+
+	"github.com/rafaelespinoza/ged/internal/entity"
+)
+
+// stepLink is a union of a parent with a step-parent of a child.
+type stepLink struct {
+	parentID, stepParentID string
+	union                  *entity.Union
+}
+
+// stepLinks finds the step-parents of the person at childID. A step-parent is
+// a partner of a parent, who is not a parent themselves. If a parent is in any
+// known unions, then the union must not have ended before the child was born,
+// or started after the child died. Otherwise, each spouse of the parent is
+// assumed to be a step-parent.
+func (r *relator) stepLinks(childID string) (out []stepLink) {
+	parentIDs := r.parentIDs(childID)
+	isStepParent := func(id string) bool {
+		return !slices.Contains(parentIDs, id) && !slices.ContainsFunc(out, func(link stepLink) bool { return link.stepParentID == id })
+	}
+
+	for _, parentID := range parentIDs {
+		unions := r.graph.UnionsAsPartner(parentID)
+		if len(unions) < 1 {
+			for _, spouseID := range r.graph.spouseIDs[parentID] {
+				if isStepParent(spouseID) {
+					out = append(out, stepLink{parentID: parentID, stepParentID: spouseID})
+				}
+			}
+			continue
+		}
+
+		for _, union := range unions {
+			if !r.duringLifeOf(union, childID) {
+				continue
+			}
+			for _, partner := range union.People() {
+				if partner.ID != parentID && isStepParent(partner.ID) {
+					out = append(out, stepLink{parentID: parentID, stepParentID: partner.ID, union: union})
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// duringLifeOf tells if the union could have lasted during any part of the life
+// of the person at id. It's only false if the dates show otherwise.
+func (r *relator) duringLifeOf(union *entity.Union, id string) bool {
+	person, ok := r.lookupOne(id)
+	if !ok {
+		return true
+	}
+
+	_, endHi, _, hasEndHi := union.EndDate.Years()
+	birthLo, _, hasBirthLo, _ := person.Birthdate.Years()
+	if hasEndHi && hasBirthLo && endHi < birthLo {
+		return false
+	}
+
+	startLo, _, hasStartLo, _ := union.StartDate.Years()
+	_, deathHi, _, hasDeathHi := person.Deathdate.Years()
+	if hasStartLo && hasDeathHi && startLo > deathHi {
+		return false
+	}
+
+	return true
+}
+
+// relateSteps finds the step relationships between the people. Either one is a
+// step-parent of the other, or a parent of one is in a union with a parent of
+// the other. Step-siblings do not have a parent in common.
+func (r *relator) relateSteps(p1ID, p2ID string) (out []rankedRelationship) {
+	for _, pair := range [][2]string{{p1ID, p2ID}, {p2ID, p1ID}} {
+		childID, otherID := pair[0], pair[1]
+		for _, link := range r.stepLinks(childID) {
+			if link.stepParentID != otherID {
+				continue
+			}
+			child, stepParent := r.newStepRelationships(childID, otherID, link, entity.StepChild, entity.StepParent)
+			if childID == p1ID {
+				out = append(out, r.newStepMutualRelationship(link, child, stepParent, 2))
+			} else {
+				out = append(out, r.newStepMutualRelationship(link, stepParent, child, 2))
+			}
+		}
+	}
+
+	p1ParentIDs, p2ParentIDs := r.parentIDs(p1ID), r.parentIDs(p2ID)
+	if slices.ContainsFunc(p1ParentIDs, func(id string) bool { return slices.Contains(p2ParentIDs, id) }) {
+		return // full or half siblings
+	}
+	for _, link := range r.stepLinks(p1ID) {
+		if !slices.Contains(p2ParentIDs, link.stepParentID) {
+			continue
+		}
+		if link.union != nil && !r.duringLifeOf(link.union, p2ID) {
+			continue
+		}
+		r1, r2 := r.newStepRelationships(p1ID, p2ID, link, entity.StepSibling, entity.StepSibling)
+		r2.Path = append(r2.Path, r.pathPerson(link.stepParentID))
+		out = append(out, r.newStepMutualRelationship(link, r1, r2, 3))
+	}
+
+	return
+}
+
+// newStepRelationships relates the person at childID, whose parent is in the
+// link, with the person at otherID. The Path of the child goes to their parent
+// in the union. The Path of the other person is only themselves.
+func (r *relator) newStepRelationships(childID, otherID string, link stepLink, childType, otherType entity.RelationshipType) (child, other entity.Relationship) {
+	child = entity.Relationship{
+		SourceID: childID,
+		TargetID: otherID,
+		Type:     childType,
+		Path:     []entity.Person{r.pathPerson(childID), r.pathPerson(link.parentID)},
+	}
+	other = entity.Relationship{
+		SourceID: otherID,
+		TargetID: childID,
+		Type:     otherType,
+		Path:     []entity.Person{r.pathPerson(otherID)},
+	}
+	if childType == entity.StepChild {
+		child.GenerationsRemoved, other.GenerationsRemoved = 1, -1
+	}
+
+	child.Description, _, _ = describeRelationship(child.Type, r.sexOf(childID), child.GenerationsRemoved, 0)
+	other.Description, _, _ = describeRelationship(other.Type, r.sexOf(otherID), other.GenerationsRemoved, 0)
+	return
+}
+
+func (r *relator) newStepMutualRelationship(link stepLink, r1, r2 entity.Relationship, distance int) rankedRelationship {
+	union := link.union
+	if union == nil {
+		parent, _ := r.lookupOne(link.parentID)
+		stepParent, _ := r.lookupOne(link.stepParentID)
+		union = entity.NewUnionOf(parent, stepParent)
+	}
+
+	return rankedRelationship{
+		MutualRelationship: entity.MutualRelationship{Union: union, R1: r1, R2: r2},
+		distance:           distance,
+		affinal:            true,
+	}
+}
+
+// pathPerson is a copy of the person at id, with the same fields as the people
+// in the Path of a consanguineous relationship.
+func (r *relator) pathPerson(id string) entity.Person {
+	return buildCommonAncestors(r, []string{id})[0]
+}